@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"net/http"
 	"os"
@@ -11,16 +12,22 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/api"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/apikey"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/core"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/core/database"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/games"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/games/drawlosseum"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/maintenance"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/seed"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/story"
 )
 
 func main() {
-	retCode := mainLogic()
+	retCode := mainLogic(os.Args[1:])
 	os.Exit(retCode)
 }
 
-func mainLogic() int {
+func mainLogic(args []string) int {
 	logger := core.SetupLogger(os.Stdout)
 	config, err := core.NewConfig()
 	if err != nil {
@@ -29,6 +36,7 @@ func mainLogic() int {
 	}
 	core.UpdateFormatter(logger, config.App.Env)
 	core.UpdateLogLevel(logger, config.App.LogLevel)
+	api.RegisterGames(config)
 
 	db, err := database.NewMongoDB(logger,
 		config.DB.Host,
@@ -45,7 +53,28 @@ func mainLogic() int {
 
 	defer db.CloseDB()
 
-	env := &api.Env{Logger: logger, Conf: config, DB: db}
+	if len(args) > 0 && args[0] == "seed" {
+		return seedCommand(logger, config, db, args[1:])
+	}
+
+	if len(args) > 0 && args[0] == "apikey" {
+		return apiKeyCommand(logger, db, args[1:])
+	}
+
+	if len(args) > 0 && args[0] == "migrate" {
+		return migrateCommand(logger, db, args[1:])
+	}
+
+	if config.Seed.OnStartup && config.Seed.Path != "" {
+		err = applySeed(logger, db, config.Seed.Path, config.Seed.Update)
+		if err != nil {
+			logger.Errorf("Failed to apply seed %v.", err)
+			return 1
+		}
+	}
+
+	shutdown := &maintenance.Shutdown{}
+	env := &api.Env{Logger: logger, Conf: config, DB: db, Shutdown: shutdown}
 	router, err := api.Setup(env)
 	if err != nil {
 		logger.Errorf("Failed to load router %v.", err)
@@ -57,7 +86,10 @@ func mainLogic() int {
 		Handler: router,
 	}
 
-	go terminateHandler(logger, &srv, config.DB.Timeout)
+	go terminateHandler(logger, &srv, shutdown, config.DB.Timeout)
+	if config.Retention.PurgeInterval > 0 {
+		go purgeHandler(logger, db, time.Duration(config.Retention.PurgeInterval)*time.Minute)
+	}
 
 	logger.Info("The Banter Bus Management API is ready.")
 	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -69,17 +101,139 @@ func mainLogic() int {
 	return 0
 }
 
+// seedCommand applies the seed file and exits, i.e. `banter-bus-management-api seed -update`.
+func seedCommand(logger *log.Logger, config core.Conf, db database.Database, args []string) int {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	path := flags.String("path", config.Seed.Path, "path to the seed file")
+	update := flags.Bool("update", config.Seed.Update, "update existing games and questions to match the seed")
+	err := flags.Parse(args)
+	if err != nil {
+		return 1
+	}
+
+	if *path == "" {
+		logger.Error("No seed file set, use -path or the seed path in the config.")
+		return 1
+	}
+
+	err = applySeed(logger, db, *path, *update)
+	if err != nil {
+		logger.Errorf("Failed to apply seed %v.", err)
+		return 1
+	}
+
+	return 0
+}
+
+// apiKeyCommand adds an API key and prints it, i.e. `banter-bus-management-api apikey -name ops -role admin`. This is
+// how the first admin key is added, after which keys can be managed through the API.
+func apiKeyCommand(logger *log.Logger, db database.Database, args []string) int {
+	flags := flag.NewFlagSet("apikey", flag.ContinueOnError)
+	name := flags.String("name", "", "what the API key is used by")
+	role := flags.String("role", string(apikey.Admin), "the role of the API key")
+	err := flags.Parse(args)
+	if err != nil {
+		return 1
+	}
+
+	if *name == "" {
+		logger.Error("No name set for the API key, use -name.")
+		return 1
+	}
+
+	s := apikey.APIKeyService{DB: db}
+	apiKey, key, err := s.Add(*name, apikey.Role(*role))
+	if err != nil {
+		logger.Errorf("Failed to add API key %v.", err)
+		return 1
+	}
+
+	logger.WithFields(log.Fields{
+		"id":   apiKey.ID,
+		"role": apiKey.Role,
+	}).Info("Added API key.")
+	fmt.Println(key)
+	return 0
+}
+
+// migrateCommand rewrites the Drawlosseum drawings that are still stored as a list of segments in the compact format
+// and exits, i.e. `banter-bus-management-api migrate -batch 100`. It can be run again if it fails part of the way.
+func migrateCommand(logger *log.Logger, db database.Database, args []string) int {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	batchSize := flags.Int64("batch", 100, "how many stories to migrate at a time")
+	err := flags.Parse(args)
+	if err != nil {
+		return 1
+	}
+
+	if *batchSize < 1 {
+		logger.Error("The batch size must be at least 1.")
+		return 1
+	}
+
+	storyService := story.StoryService{DB: db}
+	migrated, err := storyService.CompactDrawings(drawlosseum.Name, *batchSize)
+	logger.WithFields(log.Fields{
+		"migrated": migrated,
+	}).Info("Migrated drawings to the compact format.")
+	if err != nil {
+		logger.Errorf("Failed to migrate drawings %v.", err)
+		return 1
+	}
+
+	return 0
+}
+
+func applySeed(logger *log.Logger, db database.Database, path string, update bool) error {
+	seedFile, err := seed.Load(path)
+	if err != nil {
+		return err
+	}
+
+	seedService := seed.SeedService{DB: db, Logger: logger, Update: update}
+	_, err = seedService.Apply(seedFile)
+	return err
+}
+
+// purgeHandler deletes the stories that are older than the retention period of their game, every interval.
+func purgeHandler(logger *log.Logger, db database.Database, interval time.Duration) {
+	gameService := games.GameService{DB: db}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		purged, err := gameService.PurgeExpiredStories(now)
+		if err != nil {
+			logger.Errorf("Failed to purge expired stories %v.", err)
+			continue
+		}
+
+		total := 0
+		for gameName, deleted := range purged {
+			total += deleted
+			logger.WithFields(log.Fields{
+				"game_name": gameName,
+				"deleted":   deleted,
+			}).Debug("Purged expired stories.")
+		}
+		logger.WithFields(log.Fields{
+			"deleted": total,
+		}).Info("Purged expired stories.")
+	}
+}
+
 // terminateHandler waits for SIGINT or SIGTERM signals and does a graceful shutdown of the HTTP server
 // Wait for interrupt signal to gracefully shutdown the server with
 // a timeout of 5 seconds.
 // kill (no param) default send syscall.SIGTERM
 // kill -2 is syscall.SIGINT
 // kill -9 is syscall.SIGKILL but can't be catch, so don't need add it
-func terminateHandler(logger *log.Logger, srv *http.Server, timeout int) {
+func terminateHandler(logger *log.Logger, srv *http.Server, shutdown *maintenance.Shutdown, timeout int) {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	logger.Info("Shutting down HTTP server.")
+	shutdown.Start()
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
 	defer cancel()
@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"reflect"
 
 	log "github.com/sirupsen/logrus"
 
@@ -16,17 +17,33 @@ import (
 	"github.com/wI2L/fizz/openapi"
 
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/api/routes"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/apikey"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/core"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/core/database"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/games"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/games/drawlosseum"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/games/fibbingit"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/games/quibly"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/maintenance"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/questions"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/story"
 )
 
 type Env struct {
-	Conf   core.Conf
-	Logger *log.Logger
-	DB     database.Database
+	Conf     core.Conf
+	Logger   *log.Logger
+	DB       database.Database
+	Shutdown *maintenance.Shutdown
+}
+
+// RegisterGames adds every game the API supports to the game registry. The registry is global, so call it once at
+// startup, before Setup or any command that reads stories or questions.
+func RegisterGames(conf core.Conf) {
+	games.Register(
+		quibly.Game(),
+		fibbingit.Game(),
+		drawlosseum.Game(drawlosseum.Canvas{Width: conf.Drawing.CanvasWidth, Height: conf.Drawing.CanvasHeight}),
+	)
 }
 
 func Setup(env *Env) (*fizz.Fizz, error) {
@@ -39,6 +56,7 @@ func Setup(env *Env) (*fizz.Fizz, error) {
 	engine.Use(cors.Default())
 
 	engine.Use(ginlogrus.Logger(env.Logger))
+	engine.Use(authenticate(env))
 	fizzApp := fizz.NewFromEngine(engine)
 
 	infos := &openapi.Info{
@@ -47,24 +65,51 @@ func Setup(env *Env) (*fizz.Fizz, error) {
 		Version:     "1.0.0",
 	}
 
-	fizzApp.GET("/openapi", nil, fizzApp.OpenAPI(infos, "yaml"))
+	fizzApp.Generator().SetInfo(infos)
+	access := routes.NewAccess()
+	fizzApp.GET("/openapi", nil, openAPI(fizzApp, access))
+	routes.MaintenanceRoutes(&maintenance.MaintenanceAPI{
+		Conf:     env.Conf,
+		Logger:   env.Logger,
+		DB:       env.DB,
+		Shutdown: env.Shutdown,
+	}, fizzApp.Group("", "maintenance", "Related to the health of the API."))
+
 	routes.GameRoutes(&games.GameAPI{
 		Conf:   env.Conf,
 		Logger: env.Logger,
 		DB:     env.DB,
-	}, fizzApp.Group("/game", "game", "Related to managing games."))
+	}, fizzApp.Group("/game", "game", "Related to managing games."), access)
 
 	routes.QuestionRoutes(&questions.QuestionAPI{
 		Conf:   env.Conf,
 		Logger: env.Logger,
 		DB:     env.DB,
-	}, fizzApp.Group("/game/:game_name/question", "question", "Related to managing the questions."))
+	}, fizzApp.Group("/game/:game_name/question", "question", "Related to managing the questions."), access)
 
 	routes.StoryRoutes(&story.StoryAPI{
 		Conf:   env.Conf,
 		Logger: env.Logger,
 		DB:     env.DB,
-	}, fizzApp.Group("/story/:game_name", "story", "Related to managing the stories."))
+	}, fizzApp.Group("/story/:game_name", "story", "Related to managing the stories."), access)
+
+	routes.ShareRoutes(&story.StoryAPI{
+		Conf:   env.Conf,
+		Logger: env.Logger,
+		DB:     env.DB,
+	}, fizzApp.Group("/share", "share", "Related to viewing shared stories."))
+
+	routes.PlayerRoutes(&story.StoryAPI{
+		Conf:   env.Conf,
+		Logger: env.Logger,
+		DB:     env.DB,
+	}, fizzApp.Group("/player", "player", "Related to the data of players."), access)
+
+	routes.APIKeyRoutes(&apikey.APIKeyAPI{
+		Conf:   env.Conf,
+		Logger: env.Logger,
+		DB:     env.DB,
+	}, fizzApp.Group("/apikey", "apikey", "Related to managing API keys, only admins can use these."), access)
 
 	if len(fizzApp.Errors()) != 0 {
 		return nil, fmt.Errorf("fizz errors: %v", fizzApp.Errors())
@@ -76,9 +121,59 @@ func Setup(env *Env) (*fizz.Fizz, error) {
 	}
 
 	tonic.SetErrorHook(errHook)
+	tonic.SetRenderHook(renderHook, "")
 	return fizzApp, nil
 }
 
+// authenticate checks the API key of the request, if it has one, and stores its role for the routes that require a
+// role. Requests without a key can only use routes that don't require one, i.e. probes and shared stories.
+func authenticate(env *Env) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(apikey.Header)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		s := apikey.APIKeyService{DB: env.DB}
+		apiKey, err := s.Authenticate(key)
+		if err != nil {
+			env.Logger.WithFields(log.Fields{
+				"path": c.FullPath(),
+			}).Warn("Invalid API key.")
+			c.AbortWithStatusJSON(errHook(c, err))
+			return
+		}
+
+		apikey.SetRole(c, apiKey.Role)
+		c.Next()
+	}
+}
+
+// renderHook skips rendering when the handler already wrote the response itself, a handler returning a nil pointer
+// is wrapped in a non-nil interface so it has to be checked with reflection.
+func renderHook(c *gin.Context, statusCode int, payload interface{}) {
+	if isNil(payload) && c.Writer.Written() {
+		return
+	}
+
+	tonic.DefaultRenderHook(c, statusCode, payload)
+}
+
+func isNil(payload interface{}) bool {
+	if payload == nil {
+		return true
+	}
+
+	value := reflect.ValueOf(payload)
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return value.IsNil()
+	default:
+		return false
+	}
+}
+
 func errHook(_ *gin.Context, e error) (int, interface{}) {
 	code, msg := http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError)
 
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wI2L/fizz"
+	"github.com/wI2L/fizz/openapi"
+
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/api/routes"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/apikey"
+)
+
+const securitySchemeName = "apiKey"
+
+// securedOpenAPI is the OpenAPI specification generated by fizz, with the API key security scheme which fizz doesn't
+// support. The fields are in the same order as openapi.OpenAPI, so the specification is otherwise unchanged.
+type securedOpenAPI struct {
+	OpenAPI    string                      `yaml:"openapi"`
+	Info       *openapi.Info               `yaml:"info"`
+	Servers    []*openapi.Server           `yaml:"servers,omitempty"`
+	Paths      map[string]*securedPathItem `yaml:"paths"`
+	Components securedComponents           `yaml:"components"`
+	Tags       []*openapi.Tag              `yaml:"tags,omitempty"`
+	XTagGroups []*openapi.XTagGroup        `yaml:"x-tagGroups,omitempty"`
+}
+
+type securedComponents struct {
+	openapi.Components `yaml:",inline"`
+	SecuritySchemes    map[string]securityScheme `yaml:"securitySchemes"`
+}
+
+type securityScheme struct {
+	Type        string `yaml:"type"`
+	In          string `yaml:"in"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+// securedPathItem is openapi.PathItem with the operations that need an API key, the fields are in the same order.
+type securedPathItem struct {
+	Ref         string                    `yaml:"$ref,omitempty"`
+	Summary     string                    `yaml:"summary,omitempty"`
+	Description string                    `yaml:"description,omitempty"`
+	GET         *securedOperation         `yaml:"get,omitempty"`
+	PUT         *securedOperation         `yaml:"put,omitempty"`
+	POST        *securedOperation         `yaml:"post,omitempty"`
+	DELETE      *securedOperation         `yaml:"delete,omitempty"`
+	OPTIONS     *securedOperation         `yaml:"options,omitempty"`
+	HEAD        *securedOperation         `yaml:"head,omitempty"`
+	PATCH       *securedOperation         `yaml:"patch,omitempty"`
+	TRACE       *securedOperation         `yaml:"trace,omitempty"`
+	Servers     []*openapi.Server         `yaml:"servers,omitempty"`
+	Parameters  []*openapi.ParameterOrRef `yaml:"parameters,omitempty"`
+}
+
+// securedOperation is an operation with the security requirement and roles of the routes that need an API key,
+// public routes have neither.
+type securedOperation struct {
+	openapi.Operation `yaml:",inline"`
+	Security          []map[string][]string `yaml:"security,omitempty"`
+	XRoles            []apikey.Role         `yaml:"x-roles,omitempty"`
+}
+
+func openAPI(fizzApp *fizz.Fizz, access *routes.Access) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		spec := fizzApp.Generator().API()
+		secured := securedOpenAPI{
+			OpenAPI:    spec.OpenAPI,
+			Info:       spec.Info,
+			Servers:    spec.Servers,
+			Paths:      securePaths(spec.Paths, access.OperationRoles()),
+			Tags:       spec.Tags,
+			XTagGroups: spec.XTagGroups,
+			Components: securedComponents{
+				SecuritySchemes: map[string]securityScheme{
+					securitySchemeName: {
+						Type: "apiKey",
+						In:   "header",
+						Name: apikey.Header,
+						Description: "Routes with x-roles need an API key with one of those roles, admins can use " +
+							"every route.",
+					},
+				},
+			},
+		}
+
+		if spec.Components != nil {
+			secured.Components.Components = *spec.Components
+		}
+
+		c.YAML(http.StatusOK, secured)
+	}
+}
+
+func securePaths(paths openapi.Paths, roles map[string][]apikey.Role) map[string]*securedPathItem {
+	securedPaths := map[string]*securedPathItem{}
+	for path, item := range paths {
+		securedPaths[path] = &securedPathItem{
+			Ref:         item.Ref,
+			Summary:     item.Summary,
+			Description: item.Description,
+			GET:         secureOperation(item.GET, roles),
+			PUT:         secureOperation(item.PUT, roles),
+			POST:        secureOperation(item.POST, roles),
+			DELETE:      secureOperation(item.DELETE, roles),
+			OPTIONS:     secureOperation(item.OPTIONS, roles),
+			HEAD:        secureOperation(item.HEAD, roles),
+			PATCH:       secureOperation(item.PATCH, roles),
+			TRACE:       secureOperation(item.TRACE, roles),
+			Servers:     item.Servers,
+			Parameters:  item.Parameters,
+		}
+	}
+
+	return securedPaths
+}
+
+func secureOperation(operation *openapi.Operation, roles map[string][]apikey.Role) *securedOperation {
+	if operation == nil {
+		return nil
+	}
+
+	secured := &securedOperation{Operation: *operation}
+	allowed, ok := roles[operation.ID]
+	if !ok {
+		return secured
+	}
+
+	secured.Security = []map[string][]string{{securitySchemeName: {}}}
+	secured.XRoles = []apikey.Role{apikey.Admin}
+	for _, role := range allowed {
+		if role != apikey.Admin {
+			secured.XRoles = append(secured.XRoles, role)
+		}
+	}
+
+	return secured
+}
@@ -0,0 +1,92 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/loopfz/gadgeto/tonic"
+	"github.com/wI2L/fizz"
+	"github.com/wI2L/fizz/openapi"
+
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/apikey"
+)
+
+// anyRole is every role other than admin, as admins can use every route anyway.
+var anyRole = []apikey.Role{apikey.Editor, apikey.Translator, apikey.GameServer}
+
+// Access enforces and documents the roles that can use each route that needs an API key. The roles of a route are only
+// given once, to Handle, so the roles in the specification are always the roles that are enforced.
+type Access struct {
+	operations map[*openapi.OperationInfo][]apikey.Role
+}
+
+func NewAccess() *Access {
+	return &Access{operations: map[*openapi.OperationInfo][]apikey.Role{}}
+}
+
+// routeAdder adds a route to a router group, i.e. fizz.RouterGroup.GET.
+type routeAdder func(path string, infos []fizz.OperationOption, handlers ...gin.HandlerFunc) *fizz.RouterGroup
+
+// Handle adds a route that only API keys with one of the roles (or admin) can use.
+func (access *Access) Handle(
+	add routeAdder,
+	path string,
+	options []fizz.OperationOption,
+	handler gin.HandlerFunc,
+	allowed ...apikey.Role,
+) {
+	options = append(options, func(info *openapi.OperationInfo) {
+		access.operations[info] = allowed
+	})
+	add(path, options, requires(allowed...), handler)
+}
+
+// OperationRoles returns the roles that can use each route that needs an API key, by the ID of its operation. The
+// operation IDs are only set after the options are applied, so they are looked up here rather than in Handle.
+func (access *Access) OperationRoles() map[string][]apikey.Role {
+	byID := map[string][]apikey.Role{}
+	for info, allowed := range access.operations {
+		byID[info.ID] = allowed
+	}
+
+	return byID
+}
+
+// requires only lets API keys with one of the roles (or admin) use the route, it must come before the route's handler.
+func requires(roles ...apikey.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, ok := apikey.GetRole(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, APIError{
+				Message: fmt.Sprintf("missing API key, set the %s header", apikey.Header),
+			})
+			return
+		}
+
+		if !apikey.HasRole(role, roles) {
+			c.AbortWithStatusJSON(http.StatusForbidden, APIError{
+				Message: fmt.Sprintf("API keys with the role %s cannot use this route", role),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func APIKeyRoutes(env *apikey.APIKeyAPI, grp *fizz.RouterGroup, access *Access) {
+	access.Handle(grp.POST, "", []fizz.OperationOption{
+		fizz.Summary("Add an API key, the key is only returned once."),
+		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
+	}, tonic.Handler(env.AddAPIKey, http.StatusCreated), apikey.Admin)
+
+	access.Handle(grp.GET, "", []fizz.OperationOption{
+		fizz.Summary("Get all the API keys, without the keys themselves."),
+	}, tonic.Handler(env.GetAPIKeys, http.StatusOK), apikey.Admin)
+
+	access.Handle(grp.DELETE, "/:key_id", []fizz.OperationOption{
+		fizz.Summary("Revoke an API key."),
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "API key not found", APIError{}, nil, nil),
+	}, tonic.Handler(env.RemoveAPIKey, http.StatusOK), apikey.Admin)
+}
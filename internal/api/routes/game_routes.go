@@ -7,39 +7,75 @@ import (
 	"github.com/loopfz/gadgeto/tonic"
 	"github.com/wI2L/fizz"
 
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/apikey"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/games"
 )
 
-func GameRoutes(env *games.GameAPI, grp *fizz.RouterGroup) {
-	grp.POST("", []fizz.OperationOption{
+func GameRoutes(env *games.GameAPI, grp *fizz.RouterGroup, access *Access) {
+	access.Handle(grp.POST, "", []fizz.OperationOption{
 		fizz.Summary("Create a new game."),
 		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
 		fizz.Response(fmt.Sprint(http.StatusConflict), "Game already exists", APIError{}, nil, nil),
 		fizz.Deprecated(true),
-	}, tonic.Handler(env.AddGame, http.StatusCreated))
+	}, tonic.Handler(env.AddGame, http.StatusCreated), apikey.Admin)
 
-	grp.GET("", []fizz.OperationOption{
-		fizz.Summary("Get all games."),
-	}, tonic.Handler(env.GetGames, http.StatusOK))
+	access.Handle(grp.GET, "", []fizz.OperationOption{
+		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Invalid language or region", APIError{}, nil, nil),
+		fizz.Summary("Get all games, optionally only those available in a language or region."),
+	}, tonic.Handler(env.GetGames, http.StatusOK), anyRole...)
 
-	grp.GET("/:game_name", []fizz.OperationOption{
+	access.Handle(grp.GET, "/:game_name", []fizz.OperationOption{
 		fizz.Response(fmt.Sprint(http.StatusNotFound), "Game doesn't exist", APIError{}, nil, nil),
 		fizz.Summary("Get a game."),
-	}, tonic.Handler(env.GetGame, http.StatusOK))
+	}, tonic.Handler(env.GetGame, http.StatusOK), anyRole...)
 
-	grp.DELETE("/:game_name", []fizz.OperationOption{
+	access.Handle(grp.PATCH, "/:game_name", []fizz.OperationOption{
+		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Game doesn't exist", APIError{}, nil, nil),
+		fizz.Summary("Update the metadata of a game, only the fields that are set are updated."),
+	}, tonic.Handler(env.UpdateGameMetadata, http.StatusOK), apikey.Editor)
+
+	access.Handle(grp.DELETE, "/:game_name", []fizz.OperationOption{
+		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Missing or invalid confirmation token", APIError{}, nil, nil),
 		fizz.Response(fmt.Sprint(http.StatusNotFound), "Game doesn't exist", APIError{}, nil, nil),
-		fizz.Summary("Delete a game."),
+		fizz.Summary("Delete a game, along with its questions, stories and their share links."),
+		fizz.Description(
+			"Use dry_run to preview what would be removed, the confirmation token it returns must be passed to remove the game. " +
+				"The token expires after 10 minutes.",
+		),
 		fizz.Deprecated(true),
-	}, tonic.Handler(env.RemoveGame, http.StatusOK))
+	}, tonic.Handler(env.RemoveGame, http.StatusOK), apikey.Admin)
+
+	access.Handle(grp.GET, "/:game_name/stats", []fizz.OperationOption{
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Game doesn't exist", APIError{}, nil, nil),
+		fizz.Summary("Get the statistics of the questions and stories of a game."),
+	}, tonic.Handler(env.GetGameStats, http.StatusOK), anyRole...)
+
+	access.Handle(grp.GET, "/:game_name/rules", []fizz.OperationOption{
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Game doesn't exist", APIError{}, nil, nil),
+		fizz.Summary("Get the rules (rounds) of a game."),
+	}, tonic.Handler(env.GetGameRules, http.StatusOK), anyRole...)
+
+	access.Handle(grp.PUT, "/:game_name/rules", []fizz.OperationOption{
+		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Game doesn't exist", APIError{}, nil, nil),
+		fizz.Summary("Update the rules (rounds) of a game."),
+	}, tonic.Handler(env.UpdateGameRules, http.StatusOK), apikey.Editor)
+
+	access.Handle(grp.GET, "/:game_name/round", []fizz.OperationOption{
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Game doesn't exist", APIError{}, nil, nil),
+		fizz.Summary("Get the rounds of a game, including example questions for each round."),
+	}, tonic.Handler(env.GetRounds, http.StatusOK), anyRole...)
 
-	grp.PUT("/:game_name/enable", []fizz.OperationOption{
+	access.Handle(grp.PUT, "/:game_name/enable", []fizz.OperationOption{
 		fizz.Response(fmt.Sprint(http.StatusNotFound), "Game doesn't exist", APIError{}, nil, nil),
-		fizz.Summary("Enables a game."),
-	}, tonic.Handler(env.EnableGame, http.StatusOK))
+		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Invalid language", APIError{}, nil, nil),
+		fizz.Summary("Enables a game, or only a single language of the game."),
+	}, tonic.Handler(env.EnableGame, http.StatusOK), apikey.Editor)
 
-	grp.PUT("/:game_name/disable", []fizz.OperationOption{
+	access.Handle(grp.PUT, "/:game_name/disable", []fizz.OperationOption{
 		fizz.Response(fmt.Sprint(http.StatusNotFound), "Game doesn't exist", APIError{}, nil, nil),
-		fizz.Summary("Disables a game."),
-	}, tonic.Handler(env.DisableGame, http.StatusOK))
+		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Invalid language", APIError{}, nil, nil),
+		fizz.Summary("Disables a game, or only a single language of the game."),
+	}, tonic.Handler(env.DisableGame, http.StatusOK), apikey.Editor)
 }
@@ -15,4 +15,13 @@ func MaintenanceRoutes(env *maintenance.MaintenanceAPI, grp *fizz.RouterGroup) {
 		fizz.Summary("Checks Banter Bus API is healthy."),
 		fizz.Response(fmt.Sprint(http.StatusInternalServerError), "Server Error", nil, nil, nil),
 	}, tonic.Handler(env.Healthcheck, http.StatusOK))
+
+	grp.GET("/livez", []fizz.OperationOption{
+		fizz.Summary("Checks Banter Bus API is running."),
+	}, tonic.Handler(env.Livez, http.StatusOK))
+
+	grp.GET("/readyz", []fizz.OperationOption{
+		fizz.Summary("Checks Banter Bus API can serve requests, with the result of each check."),
+		fizz.Response(fmt.Sprint(http.StatusServiceUnavailable), "Not ready", maintenance.Readiness{}, nil, nil),
+	}, tonic.Handler(env.Readyz, http.StatusOK))
 }
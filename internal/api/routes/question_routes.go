@@ -7,11 +7,12 @@ import (
 	"github.com/loopfz/gadgeto/tonic"
 	"github.com/wI2L/fizz"
 
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/apikey"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/questions"
 )
 
-func QuestionRoutes(env *questions.QuestionAPI, grp *fizz.RouterGroup) {
-	grp.POST("", []fizz.OperationOption{
+func QuestionRoutes(env *questions.QuestionAPI, grp *fizz.RouterGroup, access *Access) {
+	access.Handle(grp.POST, "", []fizz.OperationOption{
 		fizz.Summary("Add a new question to a game."),
 		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
 		fizz.Response(fmt.Sprint(http.StatusNotFound), "Game doesn't exist", APIError{}, nil, nil),
@@ -22,9 +23,9 @@ func QuestionRoutes(env *questions.QuestionAPI, grp *fizz.RouterGroup) {
 			nil,
 			nil,
 		),
-	}, tonic.Handler(env.AddQuestion, http.StatusCreated))
+	}, tonic.Handler(env.AddQuestion, http.StatusCreated), apikey.Editor)
 
-	grp.DELETE("/:question_id", []fizz.OperationOption{
+	access.Handle(grp.DELETE, "/:question_id", []fizz.OperationOption{
 		fizz.Summary("Remove a question from a game."),
 		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
 		fizz.Response(
@@ -34,23 +35,23 @@ func QuestionRoutes(env *questions.QuestionAPI, grp *fizz.RouterGroup) {
 			nil,
 			nil,
 		),
-	}, tonic.Handler(env.RemoveQuestion, http.StatusOK))
+	}, tonic.Handler(env.RemoveQuestion, http.StatusOK), apikey.Editor)
 
-	getRoutes(env, grp)
-	translationRoutes(env, grp)
-	updateRoutes(env, grp)
+	getRoutes(env, grp, access)
+	translationRoutes(env, grp, access)
+	updateRoutes(env, grp, access)
 }
 
-func getRoutes(env *questions.QuestionAPI, grp *fizz.RouterGroup) {
-	grp.GET("", []fizz.OperationOption{
+func getRoutes(env *questions.QuestionAPI, grp *fizz.RouterGroup, access *Access) {
+	access.Handle(grp.GET, "", []fizz.OperationOption{
 		fizz.Summary("Gets a list of questions."),
 		fizz.Response(
 			fmt.Sprint(http.StatusNotFound), "Game or round does not exist",
 			APIError{}, nil, nil,
 		),
-	}, tonic.Handler(env.GetQuestions, http.StatusOK))
+	}, tonic.Handler(env.GetQuestions, http.StatusOK), anyRole...)
 
-	grp.GET("/group", []fizz.OperationOption{
+	access.Handle(grp.GET, "/group", []fizz.OperationOption{
 		fizz.Summary("Get a list of question groups."),
 		fizz.Response(
 			fmt.Sprint(http.StatusNotFound),
@@ -59,9 +60,9 @@ func getRoutes(env *questions.QuestionAPI, grp *fizz.RouterGroup) {
 			nil,
 			nil,
 		),
-	}, tonic.Handler(env.GetAllGroups, http.StatusOK))
+	}, tonic.Handler(env.GetAllGroups, http.StatusOK), anyRole...)
 
-	grp.GET("/id", []fizz.OperationOption{
+	access.Handle(grp.GET, "/id", []fizz.OperationOption{
 		fizz.Summary("Get all questions IDs for a game."),
 		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
 		fizz.Response(
@@ -71,9 +72,9 @@ func getRoutes(env *questions.QuestionAPI, grp *fizz.RouterGroup) {
 			nil,
 			nil,
 		),
-	}, tonic.Handler(env.GetQuestionsIDs, http.StatusOK))
+	}, tonic.Handler(env.GetQuestionsIDs, http.StatusOK), anyRole...)
 
-	grp.GET("/language", []fizz.OperationOption{
+	access.Handle(grp.GET, "/language", []fizz.OperationOption{
 		fizz.Summary("Get all languages used for all questions."),
 		fizz.Response(
 			fmt.Sprint(http.StatusNotFound),
@@ -82,9 +83,21 @@ func getRoutes(env *questions.QuestionAPI, grp *fizz.RouterGroup) {
 			nil,
 			nil,
 		),
-	}, tonic.Handler(env.GetAllLanguages, http.StatusOK))
+	}, tonic.Handler(env.GetAllLanguages, http.StatusOK), anyRole...)
 
-	grp.GET("/:question_id/:language", []fizz.OperationOption{
+	access.Handle(grp.GET, "/:question_id", []fizz.OperationOption{
+		fizz.Summary("Get a single question with all of its translations."),
+		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
+		fizz.Response(
+			fmt.Sprint(http.StatusNotFound),
+			"Game or question doesn't exist.",
+			APIError{},
+			nil,
+			nil,
+		),
+	}, tonic.Handler(env.GetFullQuestion, http.StatusOK), anyRole...)
+
+	access.Handle(grp.GET, "/:question_id/:language", []fizz.OperationOption{
 		fizz.Summary("Get a single question."),
 		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
 		fizz.Response(
@@ -94,11 +107,23 @@ func getRoutes(env *questions.QuestionAPI, grp *fizz.RouterGroup) {
 			nil,
 			nil,
 		),
-	}, tonic.Handler(env.GetQuestion, http.StatusOK))
+	}, tonic.Handler(env.GetQuestion, http.StatusOK), anyRole...)
+
+	access.Handle(grp.POST, "/:question_id/:language/render", []fizz.OperationOption{
+		fizz.Summary("Render a question, substituting values into its placeholders."),
+		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
+		fizz.Response(
+			fmt.Sprint(http.StatusNotFound),
+			"Game, question or language code (for that question) doesn't exist.",
+			APIError{},
+			nil,
+			nil,
+		),
+	}, tonic.Handler(env.RenderQuestion, http.StatusOK), anyRole...)
 }
 
-func translationRoutes(env *questions.QuestionAPI, grp *fizz.RouterGroup) {
-	grp.POST("/:question_id/:language", []fizz.OperationOption{
+func translationRoutes(env *questions.QuestionAPI, grp *fizz.RouterGroup, access *Access) {
+	access.Handle(grp.POST, "/:question_id/:language", []fizz.OperationOption{
 		fizz.Summary("Adds a new question translation."),
 		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
 		fizz.Response(
@@ -108,9 +133,9 @@ func translationRoutes(env *questions.QuestionAPI, grp *fizz.RouterGroup) {
 			nil,
 			nil,
 		),
-	}, tonic.Handler(env.AddTranslation, http.StatusCreated))
+	}, tonic.Handler(env.AddTranslation, http.StatusCreated), apikey.Editor, apikey.Translator)
 
-	grp.DELETE("/:question_id/:language", []fizz.OperationOption{
+	access.Handle(grp.DELETE, "/:question_id/:language", []fizz.OperationOption{
 		fizz.Summary("Remove a question translation from a game."),
 		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
 		fizz.Response(
@@ -120,11 +145,11 @@ func translationRoutes(env *questions.QuestionAPI, grp *fizz.RouterGroup) {
 			nil,
 			nil,
 		),
-	}, tonic.Handler(env.RemoveTranslation, http.StatusOK))
+	}, tonic.Handler(env.RemoveTranslation, http.StatusOK), apikey.Editor, apikey.Translator)
 }
 
-func updateRoutes(env *questions.QuestionAPI, grp *fizz.RouterGroup) {
-	grp.PUT("/:question_id/enable", []fizz.OperationOption{
+func updateRoutes(env *questions.QuestionAPI, grp *fizz.RouterGroup, access *Access) {
+	access.Handle(grp.PUT, "/:question_id/enable", []fizz.OperationOption{
 		fizz.Summary("Enables a question."),
 		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
 		fizz.Response(
@@ -134,9 +159,9 @@ func updateRoutes(env *questions.QuestionAPI, grp *fizz.RouterGroup) {
 			nil,
 			nil,
 		),
-	}, tonic.Handler(env.EnableQuestion, http.StatusOK))
+	}, tonic.Handler(env.EnableQuestion, http.StatusOK), apikey.Editor)
 
-	grp.PUT("/:question_id/disable", []fizz.OperationOption{
+	access.Handle(grp.PUT, "/:question_id/disable", []fizz.OperationOption{
 		fizz.Summary("Disabled a question."),
 		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
 		fizz.Response(
@@ -146,5 +171,5 @@ func updateRoutes(env *questions.QuestionAPI, grp *fizz.RouterGroup) {
 			nil,
 			nil,
 		),
-	}, tonic.Handler(env.DisableQuestion, http.StatusOK))
+	}, tonic.Handler(env.DisableQuestion, http.StatusOK), apikey.Editor)
 }
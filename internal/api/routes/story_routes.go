@@ -7,21 +7,117 @@ import (
 	"github.com/loopfz/gadgeto/tonic"
 	"github.com/wI2L/fizz"
 
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/apikey"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/story"
 )
 
-func StoryRoutes(env *story.StoryAPI, grp *fizz.RouterGroup) {
-	grp.POST("", []fizz.OperationOption{
+func StoryRoutes(env *story.StoryAPI, grp *fizz.RouterGroup, access *Access) {
+	access.Handle(grp.POST, "", []fizz.OperationOption{
 		fizz.Summary("Add a story."),
-	}, tonic.Handler(env.AddStory, http.StatusCreated))
+	}, tonic.Handler(env.AddStory, http.StatusCreated), apikey.GameServer)
 
-	grp.GET("/:story_id", []fizz.OperationOption{
+	access.Handle(grp.GET, "", []fizz.OperationOption{
+		fizz.Summary("List the stories of a game."),
+		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Game not found", APIError{}, nil, nil),
+	}, tonic.Handler(env.ListStories, http.StatusOK), anyRole...)
+
+	access.Handle(grp.POST, "/purge", []fizz.OperationOption{
+		fizz.Summary("Delete the stories of a game added before a given time."),
+		fizz.Response(
+			fmt.Sprint(http.StatusBadRequest),
+			"Bad Request, or redacting without the privacy pseudonym key set",
+			APIError{},
+			nil,
+			nil,
+		),
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Game not found", APIError{}, nil, nil),
+	}, tonic.Handler(env.PurgeStories, http.StatusOK), apikey.Admin)
+
+	access.Handle(grp.GET, "/highlights", []fizz.OperationOption{
+		fizz.Summary("Get the featured stories of a game, and the stories with the most likes over a time range."),
+		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Game not found", APIError{}, nil, nil),
+	}, tonic.Handler(env.GetHighlights, http.StatusOK), anyRole...)
+
+	access.Handle(grp.GET, "/player/:nickname/best", []fizz.OperationOption{
+		fizz.Summary("Get the answers of a player with the most votes, only quibly answers are voted on."),
+		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Game answers are not voted on", APIError{}, nil, nil),
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Game not found", APIError{}, nil, nil),
+	}, tonic.Handler(env.GetBestAnswers, http.StatusOK), anyRole...)
+
+	access.Handle(grp.GET, "/:story_id", []fizz.OperationOption{
 		fizz.Summary("Get a story."),
 		fizz.Response(fmt.Sprint(http.StatusNotFound), "Story not found", APIError{}, nil, nil),
-	}, tonic.Handler(env.GetStory, http.StatusOK))
+	}, tonic.Handler(env.GetStory, http.StatusOK), anyRole...)
 
-	grp.DELETE("/:story_id", []fizz.OperationOption{
+	access.Handle(grp.GET, "/:story_id/image", []fizz.OperationOption{
+		fizz.Summary("Get a story as an image, only drawlosseum stories can be rendered."),
+		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Game stories cannot be rendered", APIError{}, nil, nil),
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Story not found", APIError{}, nil, nil),
+	}, tonic.Handler(env.GetStoryImage, http.StatusOK), anyRole...)
+
+	access.Handle(grp.GET, "/:story_id/transcript", []fizz.OperationOption{
+		fizz.Summary("Get a story as a transcript that can be read by people."),
+		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Story not found", APIError{}, nil, nil),
+	}, tonic.Handler(env.GetStoryTranscript, http.StatusOK), anyRole...)
+
+	access.Handle(grp.POST, "/:story_id/answers", []fizz.OperationOption{
+		fizz.Summary("Add answers, votes or drawing segments to a story that is in progress."),
+		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Story not found", APIError{}, nil, nil),
+	}, tonic.Handler(env.AppendAnswers, http.StatusOK), apikey.GameServer)
+
+	access.Handle(grp.PUT, "/:story_id/finalise", []fizz.OperationOption{
+		fizz.Summary("Mark a story that is in progress as complete."),
+		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Story is already complete", APIError{}, nil, nil),
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Story not found", APIError{}, nil, nil),
+	}, tonic.Handler(env.FinaliseStory, http.StatusOK), apikey.GameServer)
+
+	access.Handle(grp.POST, "/:story_id/share", []fizz.OperationOption{
+		fizz.Summary("Create a share link for a story."),
+		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Story not found", APIError{}, nil, nil),
+	}, tonic.Handler(env.ShareStory, http.StatusCreated), apikey.GameServer, apikey.Editor)
+
+	access.Handle(grp.DELETE, "/:story_id/share/:token", []fizz.OperationOption{
+		fizz.Summary("Revoke a share link of a story."),
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Share link not found", APIError{}, nil, nil),
+	}, tonic.Handler(env.RevokeShare, http.StatusOK), apikey.Editor)
+
+	access.Handle(grp.POST, "/:story_id/like", []fizz.OperationOption{
+		fizz.Summary("Like a story."),
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Story not found", APIError{}, nil, nil),
+	}, tonic.Handler(env.LikeStory, http.StatusOK), apikey.GameServer, apikey.Editor)
+
+	access.Handle(grp.DELETE, "/:story_id/like", []fizz.OperationOption{
+		fizz.Summary("Remove a like from a story."),
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Story not found", APIError{}, nil, nil),
+	}, tonic.Handler(env.UnlikeStory, http.StatusOK), apikey.GameServer, apikey.Editor)
+
+	access.Handle(grp.PUT, "/:story_id/featured", []fizz.OperationOption{
+		fizz.Summary("Feature a story, or stop featuring it."),
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Story not found", APIError{}, nil, nil),
+	}, tonic.Handler(env.FeatureStory, http.StatusOK), apikey.Editor)
+
+	access.Handle(grp.DELETE, "/:story_id", []fizz.OperationOption{
 		fizz.Summary("Delete a story."),
 		fizz.Response(fmt.Sprint(http.StatusNotFound), "Story not found", APIError{}, nil, nil),
-	}, tonic.Handler(env.DeleteStory, http.StatusOK))
+	}, tonic.Handler(env.DeleteStory, http.StatusOK), apikey.Editor)
+}
+
+func ShareRoutes(env *story.StoryAPI, grp *fizz.RouterGroup) {
+	grp.GET("/:token", []fizz.OperationOption{
+		fizz.Summary("Get a shared story, using the token of its share link."),
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Share link not found or expired", APIError{}, nil, nil),
+	}, tonic.Handler(env.GetSharedStory, http.StatusOK))
+}
+
+func PlayerRoutes(env *story.StoryAPI, grp *fizz.RouterGroup, access *Access) {
+	access.Handle(grp.POST, "/erase", []fizz.OperationOption{
+		fizz.Summary("Erase a player from the stories that mention their nickname, by redacting it or deleting them."),
+		fizz.Response(fmt.Sprint(http.StatusBadRequest), "Bad Request", APIError{}, nil, nil),
+		fizz.Response(fmt.Sprint(http.StatusNotFound), "Game not found", APIError{}, nil, nil),
+	}, tonic.Handler(env.ErasePlayer, http.StatusOK), apikey.Admin)
 }
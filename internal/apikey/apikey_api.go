@@ -0,0 +1,117 @@
+package apikey
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/juju/errors"
+	log "github.com/sirupsen/logrus"
+
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/core"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/core/database"
+)
+
+// Header is the request header the API key is sent in.
+const Header = "X-API-Key"
+
+const roleKey = "apikey_role"
+
+type APIKeyAPI struct {
+	Conf   core.Conf
+	Logger *log.Logger
+	DB     database.Database
+}
+
+// SetRole stores the role of the API key of the request, once it has been authenticated.
+func SetRole(c *gin.Context, role Role) {
+	c.Set(roleKey, role)
+}
+
+// GetRole gets the role of the API key of the request, it is false if the request doesn't have an API key.
+func GetRole(c *gin.Context) (Role, bool) {
+	role, ok := c.Get(roleKey)
+	if !ok {
+		return "", false
+	}
+
+	r, ok := role.(Role)
+	return r, ok
+}
+
+func (env *APIKeyAPI) AddAPIKey(_ *gin.Context, input *NewAPIKeyIn) (NewAPIKeyOut, error) {
+	keyLogger := env.Logger.WithFields(log.Fields{
+		"name": input.Name,
+		"role": input.Role,
+	})
+	keyLogger.Debug("Trying to add API key.")
+
+	s := APIKeyService{DB: env.DB}
+	apiKey, key, err := s.Add(input.Name, input.Role)
+	if errors.IsBadRequest(err) {
+		keyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Invalid API key.")
+		return NewAPIKeyOut{}, err
+	} else if err != nil {
+		keyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("Failed to add API key.")
+		return NewAPIKeyOut{}, err
+	}
+
+	keyLogger.WithFields(log.Fields{
+		"id": apiKey.ID,
+	}).Info("Added API key.")
+	return NewAPIKeyOut{Key: key, APIKeyOut: newAPIKeyOut(apiKey)}, nil
+}
+
+func (env *APIKeyAPI) GetAPIKeys(_ *gin.Context) (APIKeysOut, error) {
+	env.Logger.Debug("Trying to get API keys.")
+
+	s := APIKeyService{DB: env.DB}
+	keys, err := s.GetAll()
+	if err != nil {
+		env.Logger.WithFields(log.Fields{
+			"err": err,
+		}).Error("Failed to get API keys.")
+		return APIKeysOut{}, err
+	}
+
+	keysOut := APIKeysOut{Keys: []APIKeyOut{}}
+	for _, key := range keys {
+		keysOut.Keys = append(keysOut.Keys, newAPIKeyOut(key))
+	}
+
+	return keysOut, nil
+}
+
+func (env *APIKeyAPI) RemoveAPIKey(_ *gin.Context, params *APIKeyIDParams) error {
+	keyLogger := env.Logger.WithFields(log.Fields{
+		"id": params.ID,
+	})
+	keyLogger.Debug("Trying to remove API key.")
+
+	s := APIKeyService{DB: env.DB}
+	err := s.Remove(params.ID)
+	if errors.IsNotFound(err) {
+		keyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("API key does not exist.")
+		return err
+	} else if err != nil {
+		keyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("Failed to remove API key.")
+		return err
+	}
+
+	keyLogger.Info("Removed API key.")
+	return nil
+}
+
+func newAPIKeyOut(key APIKey) APIKeyOut {
+	return APIKeyOut{
+		ID:        key.ID,
+		Name:      key.Name,
+		Role:      key.Role,
+		CreatedAt: key.CreatedAt,
+	}
+}
@@ -0,0 +1,28 @@
+package apikey
+
+import "time"
+
+type NewAPIKeyIn struct {
+	Name string `json:"name" description:"What the API key is used by."  example:"quibly-server" validate:"required"`
+	Role Role   `json:"role" description:"Which routes the API key can use." example:"game-server"   validate:"required" enum:"admin,editor,translator,game-server"`
+}
+
+type APIKeyOut struct {
+	ID        string    `json:"id"         description:"The id of the API key."             example:"8a3e5b5ed0e64bba9e4b7ff5ccc1b0c4"`
+	Name      string    `json:"name"       description:"What the API key is used by."       example:"quibly-server"`
+	Role      Role      `json:"role"       description:"Which routes the API key can use."  example:"game-server"`
+	CreatedAt time.Time `json:"created_at" description:"When the API key was added."`
+}
+
+type NewAPIKeyOut struct {
+	Key string `json:"key" description:"The API key, send it in the X-API-Key header. It cannot be retrieved again." example:"q0Hn4dC7bkq3o3pJ0oXq3H7d7J1S4gXc0m3cN9x2sYk"`
+	APIKeyOut
+}
+
+type APIKeysOut struct {
+	Keys []APIKeyOut `json:"keys" description:"The API keys, without the keys themselves."`
+}
+
+type APIKeyIDParams struct {
+	ID string `description:"The id of the API key." example:"8a3e5b5ed0e64bba9e4b7ff5ccc1b0c4" path:"key_id"`
+}
@@ -0,0 +1,73 @@
+package apikey
+
+import (
+	"time"
+
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/core/database"
+)
+
+// Role decides which routes an API key can use, admin keys can use every route.
+type Role string
+
+const (
+	Admin      Role = "admin"
+	Editor     Role = "editor"
+	Translator Role = "translator"
+	GameServer Role = "game-server"
+)
+
+// Roles are all the roles an API key can have.
+var Roles = []Role{Admin, Editor, Translator, GameServer}
+
+// APIKey is stored with the hash of the key rather than the key itself, the key is only returned when it is added.
+type APIKey struct {
+	ID        string    `bson:"id"`
+	Name      string    `bson:"name"`
+	Role      Role      `bson:"role"`
+	Hash      string    `bson:"hash"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func (key *APIKey) Add(db database.Database) (bool, error) {
+	inserted, err := db.Insert("api_key", key)
+	return inserted, err
+}
+
+func (key *APIKey) Get(db database.Database, filter map[string]interface{}) error {
+	err := db.Get("api_key", filter, key)
+	return err
+}
+
+func (key *APIKey) Update(db database.Database, filter map[string]interface{}) (bool, error) {
+	updated, err := db.Update("api_key", filter, key)
+	return updated, err
+}
+
+type APIKeys []APIKey
+
+func (keys *APIKeys) Add(db database.Database) error {
+	err := db.InsertMultiple("api_key", keys)
+	return err
+}
+
+func (keys *APIKeys) Get(db database.Database, filter map[string]interface{}) error {
+	err := db.GetAll("api_key", filter, keys)
+	return err
+}
+
+func (keys *APIKeys) GetWithLimit(db database.Database, filter map[string]interface{}, limit int64) error {
+	return nil
+}
+
+func (keys APIKeys) Delete(db database.Database, filter map[string]interface{}) (bool, error) {
+	deleted, err := db.DeleteAll("api_key", filter)
+	return deleted, err
+}
+
+func (keys APIKeys) ToInterface() []interface{} {
+	interfaceObject := make([]interface{}, len(keys))
+	for i, item := range keys {
+		interfaceObject[i] = item
+	}
+	return interfaceObject
+}
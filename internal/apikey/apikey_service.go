@@ -0,0 +1,122 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juju/errors"
+
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/core/database"
+)
+
+type APIKeyService struct {
+	DB database.Database
+}
+
+// Add creates a new API key with the role, the key is returned so it can be given to the client, only its hash is
+// stored.
+func (s *APIKeyService) Add(name string, role Role) (APIKey, string, error) {
+	if !isRole(role) {
+		return APIKey{}, "", errors.BadRequestf("invalid role %s", role)
+	}
+
+	key, err := newKey()
+	if err != nil {
+		return APIKey{}, "", errors.Errorf("failed to create API key %v", err)
+	}
+
+	apiKey := APIKey{
+		ID:        strings.ReplaceAll(uuid.New().String(), "-", ""),
+		Name:      name,
+		Role:      role,
+		Hash:      hashKey(key),
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	inserted, err := apiKey.Add(s.DB)
+	if !inserted || err != nil {
+		return APIKey{}, "", errors.Errorf("failed to add API key %v", err)
+	}
+
+	return apiKey, key, nil
+}
+
+func (s *APIKeyService) GetAll() (APIKeys, error) {
+	keys := APIKeys{}
+	err := keys.Get(s.DB, map[string]interface{}{})
+	if err != nil {
+		return APIKeys{}, errors.Errorf("failed to get API keys %v", err)
+	}
+
+	return keys, nil
+}
+
+// Remove revokes an API key, requests using it are rejected straight away.
+func (s *APIKeyService) Remove(id string) error {
+	deleted, err := s.DB.Delete("api_key", map[string]interface{}{"id": id})
+	if err != nil {
+		return errors.Errorf("failed to remove API key %s %v", id, err)
+	} else if !deleted {
+		return errors.NotFoundf("the API key %s", id)
+	}
+
+	return nil
+}
+
+// Authenticate gets the API key with the hash of the key.
+func (s *APIKeyService) Authenticate(key string) (APIKey, error) {
+	apiKey := APIKey{}
+	err := apiKey.Get(s.DB, map[string]interface{}{"hash": hashKey(key)})
+	if err != nil {
+		return APIKey{}, errors.Unauthorizedf("invalid API key")
+	}
+
+	return apiKey, nil
+}
+
+// newKey returns a random key, it has enough entropy that a fast hash can be used to store it rather than a password
+// hash.
+func newKey() (string, error) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(key), nil
+}
+
+func hashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func isRole(role Role) bool {
+	for _, r := range Roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasRole is whether the role can use a route that requires one of the roles, admins can use every route.
+func HasRole(role Role, roles []Role) bool {
+	if role == Admin {
+		return true
+	}
+
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
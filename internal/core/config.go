@@ -26,6 +26,26 @@ type Conf struct {
 		MaxConns int    `yaml:"maxConns" env:"BANTER_BUS_DB_MAXCONNS" env-default:"50"`
 		Timeout  int    `yaml:"timeout" env:"BANTER_BUS_DB_TIMEOUT" env-default:"3"`
 	} `yaml:"database"`
+	Seed struct {
+		Path      string `yaml:"path" env:"BANTER_BUS_SEED_PATH"`
+		OnStartup bool   `yaml:"onStartup" env:"BANTER_BUS_SEED_ON_STARTUP" env-default:"false"`
+		Update    bool   `yaml:"update" env:"BANTER_BUS_SEED_UPDATE" env-default:"false"`
+	} `yaml:"seed"`
+	Retention struct {
+		PurgeInterval int `yaml:"purgeInterval" env:"BANTER_BUS_RETENTION_PURGE_INTERVAL" env-default:"60"`
+	} `yaml:"retention"`
+	Drawing struct {
+		Width       int     `yaml:"width" env:"BANTER_BUS_DRAWING_WIDTH" env-default:"800"`
+		Height      int     `yaml:"height" env:"BANTER_BUS_DRAWING_HEIGHT" env-default:"600"`
+		StrokeWidth float64 `yaml:"strokeWidth" env:"BANTER_BUS_DRAWING_STROKE_WIDTH" env-default:"4"`
+		Background  string  `yaml:"background" env:"BANTER_BUS_DRAWING_BACKGROUND" env-default:"#ffffff"`
+		// CanvasWidth and CanvasHeight are the size of the canvas players draw on, not of the rendered images.
+		CanvasWidth  int `yaml:"canvasWidth" env:"BANTER_BUS_DRAWING_CANVAS_WIDTH" env-default:"1920"`
+		CanvasHeight int `yaml:"canvasHeight" env:"BANTER_BUS_DRAWING_CANVAS_HEIGHT" env-default:"1080"`
+	} `yaml:"drawing"`
+	Privacy struct {
+		PseudonymKey string `yaml:"pseudonymKey" env:"BANTER_BUS_PRIVACY_PSEUDONYM_KEY"`
+	} `yaml:"privacy"`
 }
 
 func NewConfig() (conf Conf, err error) {
@@ -84,5 +104,23 @@ func (conf *Conf) validate() (err error) {
 		return fmt.Errorf("invalid database port %v", conf.Srv.Port)
 	}
 
+	const maxDrawingSize = 4096
+
+	if conf.Drawing.Width < 1 || conf.Drawing.Width > maxDrawingSize {
+		return fmt.Errorf("invalid drawing width %v", conf.Drawing.Width)
+	} else if conf.Drawing.Height < 1 || conf.Drawing.Height > maxDrawingSize {
+		return fmt.Errorf("invalid drawing height %v", conf.Drawing.Height)
+	} else if conf.Drawing.StrokeWidth <= 0 {
+		return fmt.Errorf("invalid drawing stroke width %v", conf.Drawing.StrokeWidth)
+	} else if conf.Drawing.CanvasWidth < 1 {
+		return fmt.Errorf("invalid drawing canvas width %v", conf.Drawing.CanvasWidth)
+	} else if conf.Drawing.CanvasHeight < 1 {
+		return fmt.Errorf("invalid drawing canvas height %v", conf.Drawing.CanvasHeight)
+	}
+
+	if conf.Retention.PurgeInterval < 0 {
+		return fmt.Errorf("invalid retention purge interval %v", conf.Retention.PurgeInterval)
+	}
+
 	return err
 }
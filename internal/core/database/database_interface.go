@@ -1,5 +1,21 @@
 package database
 
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidCursor is returned when a page is requested with a cursor that wasn't returned by GetPage.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Page selects a page of documents, newest first. If SortBy is set documents are instead sorted by the sum of that
+// field (largest first), i.e. `answers.votes`, with ties sorted newest first. Cursor is returned by the previous page.
+type Page struct {
+	Limit  int64
+	Cursor string
+	SortBy string
+}
+
 type Documents interface {
 	Add(db Database) error
 	Get(db Database, filter map[string]interface{}) error
@@ -34,6 +50,7 @@ type Database interface {
 	Get(collectionName string, filter map[string]interface{}, document Document) error
 	GetAll(collectionName string, filter map[string]interface{}, documents Documents) error
 	GetWithLimit(collectionName string, filter map[string]interface{}, limit int64, documents Documents) error
+	GetPage(collectionName string, filter map[string]interface{}, page Page, documents Documents) (string, error)
 	GetRandom(collectionName string, filter map[string]interface{}, limit int64, documents Documents) error
 	GetUniqueValues(
 		collectionName string,
@@ -41,12 +58,19 @@ type Database interface {
 		fieldName string,
 	) ([]string, error)
 	GetUniqueKeys(collectionName string, filter map[string]interface{}, fieldName string) ([]string, error)
+	CountByField(collectionName string, filter map[string]interface{}, fieldName string) (map[string]int, error)
+	CountByKey(collectionName string, filter map[string]interface{}, fieldName string) (map[string]int, error)
+	GetLatestTime(collectionName string, filter map[string]interface{}, fieldName string) (*time.Time, error)
+	Count(collectionName string, filter map[string]interface{}) (int64, error)
 	Delete(collectionName string, filter map[string]interface{}) (bool, error)
 	DeleteAll(collectionName string, filter map[string]interface{}) (bool, error)
+	DeleteMany(collectionName string, filter map[string]interface{}) (int64, error)
 	RemoveCollection(collectionName string) error
+	GetIndexes(collectionName string) ([]string, error)
 	Update(collectionName string, filter map[string]interface{}, document Document) (bool, error)
 	UpdateObject(collectionName string, filter map[string]interface{}, subDocument UpdateSubDocument) (bool, error)
 	RemoveObject(collectionName string, filter map[string]interface{}, subDocument UpdateSubDocument) (bool, error)
+	Increment(collectionName string, filter map[string]interface{}, fieldName string, amount int) (bool, error)
 	AppendToList(collectionName string, filter map[string]interface{}, subDocument NewSubDocument) (bool, error)
 	RemoveFromList(collectionName string, filter map[string]interface{}, subDocument SubDocument) (bool, error)
 }
@@ -3,15 +3,36 @@ package database
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
+// Index is an index on a single field that the API needs, see EnsureIndexes.
+type Index struct {
+	Collection string
+	Field      string
+	Unique     bool
+}
+
+// Indexes are the indexes the API needs, they are created when connecting to the database.
+var Indexes = []Index{
+	{Collection: "question", Field: "id", Unique: true},
+	{Collection: "story", Field: "id", Unique: true},
+	{Collection: "share", Field: "token", Unique: true},
+	{Collection: "removal", Field: "token", Unique: true},
+	{Collection: "api_key", Field: "hash", Unique: true},
+}
+
 type MongoDB struct {
 	*mongo.Database
 	Client   *mongo.Client
@@ -64,13 +85,9 @@ func NewMongoDB(
 	logger.Info("Connected to database.")
 	db.Client = client
 	db.Database = client.Database(name)
-	err = db.createIndex("question", "id", true)
+	err = db.EnsureIndexes()
 	if err != nil {
-		return &MongoDB{}, fmt.Errorf("error while creating index for question %w", err)
-	}
-	err = db.createIndex("story", "id", true)
-	if err != nil {
-		return &MongoDB{}, fmt.Errorf("error while creating index for story %w", err)
+		return &MongoDB{}, err
 	}
 	return db, nil
 }
@@ -303,6 +320,170 @@ func (db *MongoDB) GetUniqueKeys(
 	return unique, err
 }
 
+// CountByField counts the documents grouped by the value of the field, the value is converted to a string and
+// documents without the field are counted under an empty string.
+func (db *MongoDB) CountByField(
+	collectionName string,
+	filter map[string]interface{},
+	fieldName string,
+) (map[string]int, error) {
+	db.Logger.WithFields(log.Fields{
+		"collection": collectionName,
+		"filter":     filter,
+		"field_name": fieldName,
+	}).Debug("Counting documents by field in database.")
+
+	selector := fmt.Sprintf("$%s", fieldName)
+	pipeline := mongo.Pipeline{
+		{
+			{
+				Key: "$match", Value: filter,
+			},
+		},
+		{
+			{
+				Key: "$group",
+				Value: bson.M{
+					"_id":   selector,
+					"count": bson.M{"$sum": 1},
+				},
+			},
+		},
+	}
+
+	counts, err := db.aggregateCounts(collectionName, pipeline)
+	return counts, err
+}
+
+// CountByKey counts the documents that have each key of an object field, i.e. the languages of a question's content.
+// Only the field is read and its keys are counted as the documents are read, as `$objectToArray` isn't supported by
+// every database the API is tested against.
+func (db *MongoDB) CountByKey(
+	collectionName string,
+	filter map[string]interface{},
+	fieldName string,
+) (map[string]int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(db.Timeout)*time.Second)
+	defer cancel()
+
+	db.Logger.WithFields(log.Fields{
+		"collection": collectionName,
+		"filter":     filter,
+		"field_name": fieldName,
+	}).Debug("Counting documents by key in database.")
+
+	projection := options.Find().SetProjection(bson.M{"_id": 0, fieldName: 1})
+	cursor, err := db.Collection(collectionName).Find(ctx, filter, projection)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	counts := map[string]int{}
+	for cursor.Next(ctx) {
+		object, ok := cursor.Current.Lookup(strings.Split(fieldName, ".")...).DocumentOK()
+		if !ok {
+			continue
+		}
+
+		elements, err := object.Elements()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, element := range elements {
+			counts[element.Key()]++
+		}
+	}
+
+	return counts, cursor.Err()
+}
+
+// GetLatestTime gets the most recent time stored in a field. An object ID field is converted to the time it was
+// created. If no documents match the filter, nil is returned.
+func (db *MongoDB) GetLatestTime(
+	collectionName string,
+	filter map[string]interface{},
+	fieldName string,
+) (*time.Time, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(db.Timeout)*time.Second)
+	defer cancel()
+
+	db.Logger.WithFields(log.Fields{
+		"collection": collectionName,
+		"filter":     filter,
+		"field_name": fieldName,
+	}).Debug("Getting latest time from database.")
+
+	latest := options.FindOne().
+		SetSort(bson.D{{Key: fieldName, Value: -1}}).
+		SetProjection(bson.M{fieldName: 1})
+	raw, err := db.Collection(collectionName).FindOne(ctx, filter, latest).DecodeBytes()
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	value := raw.Lookup(strings.Split(fieldName, ".")...)
+	if id, ok := value.ObjectIDOK(); ok {
+		latestTime := id.Timestamp()
+		return &latestTime, nil
+	} else if dateTime, ok := value.DateTimeOK(); ok {
+		latestTime := time.Unix(0, dateTime*int64(time.Millisecond)).UTC()
+		return &latestTime, nil
+	}
+
+	return nil, fmt.Errorf("error while getting latest time, %s is not a time", fieldName)
+}
+
+func (db *MongoDB) aggregateCounts(collectionName string, pipeline mongo.Pipeline) (map[string]int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(db.Timeout)*time.Second)
+	defer cancel()
+
+	collection := db.Collection(collectionName)
+	aggregate, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	model := []struct {
+		Value bson.RawValue `bson:"_id"`
+		Count int           `bson:"count"`
+	}{}
+	err = aggregate.All(ctx, &model)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int{}
+	for _, item := range model {
+		counts[countKey(item.Value)] += item.Count
+	}
+
+	return counts, nil
+}
+
+// countKey converts a grouped value to the key it is counted under, missing values and values that aren't strings,
+// booleans or numbers are counted under "". The conversion is done here rather than with `$toString`, as it isn't
+// supported by every database the API is tested against.
+func countKey(value bson.RawValue) string {
+	switch value.Type {
+	case bsontype.String:
+		return value.StringValue()
+	case bsontype.Boolean:
+		return strconv.FormatBool(value.Boolean())
+	case bsontype.Int32:
+		return strconv.Itoa(int(value.Int32()))
+	case bsontype.Int64:
+		return strconv.FormatInt(value.Int64(), 10)
+	case bsontype.Double:
+		return strconv.FormatFloat(value.Double(), 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
 func (db *MongoDB) aggregate(collectionName string, pipeline mongo.Pipeline) ([]string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(db.Timeout)*time.Second)
 	defer cancel()
@@ -343,6 +524,141 @@ func (db *MongoDB) GetWithLimit(
 	return err
 }
 
+// GetPage gets a page of documents and returns the cursor for the next page, which is empty if this is the last page.
+// Pages use the `_id` of the last document (and the sort value, if sorted) rather than an offset, so documents being
+// added or removed between pages don't cause documents to be skipped or repeated.
+func (db *MongoDB) GetPage(
+	collectionName string,
+	filter map[string]interface{},
+	page Page,
+	documents Documents,
+) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(db.Timeout)*time.Second)
+	defer cancel()
+
+	db.Logger.WithFields(log.Fields{
+		"collection": collectionName,
+		"filter":     filter,
+		"page":       page,
+	}).Debug("Getting page of documents from database.")
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: filter}}}
+	sort := bson.D{{Key: "_id", Value: -1}}
+	if page.SortBy != "" {
+		pipeline = append(pipeline, bson.D{{
+			Key: "$addFields", Value: bson.M{"_sort": bson.M{"$sum": fmt.Sprintf("$%s", page.SortBy)}},
+		}})
+		sort = bson.D{{Key: "_sort", Value: -1}, {Key: "_id", Value: -1}}
+	}
+
+	if page.Cursor != "" {
+		after, err := newCursorFilter(page)
+		if err != nil {
+			return "", err
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: after}})
+	}
+
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: sort}}, bson.D{{Key: "$limit", Value: page.Limit}})
+
+	collection := db.Collection(collectionName)
+	aggregate, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		db.Logger.Errorf("failed to get page: %v", err)
+		return "", err
+	}
+
+	raws := []bson.Raw{}
+	err = aggregate.All(ctx, &raws)
+	if err != nil {
+		db.Logger.Errorf("failed to get page: %v", err)
+		return "", err
+	}
+
+	results := reflect.ValueOf(documents).Elem()
+	for _, raw := range raws {
+		item := reflect.New(results.Type().Elem())
+		err = bson.Unmarshal(raw, item.Interface())
+		if err != nil {
+			db.Logger.Errorf("failed to transform object: %v", err)
+			return "", err
+		}
+		results.Set(reflect.Append(results, item.Elem()))
+	}
+
+	if int64(len(raws)) < page.Limit || len(raws) == 0 {
+		return "", nil
+	}
+
+	cursor, err := newCursor(raws[len(raws)-1], page.SortBy != "")
+	if err != nil {
+		db.Logger.Errorf("failed to get page: %v", err)
+		return "", err
+	}
+
+	return cursor, nil
+}
+
+// newCursor returns the cursor for the page after the document, `<_id>` or `<sort value>_<_id>` if sorted. Cursors
+// use the ObjectID of the document, so documents with any other type of `_id` cannot be paged through.
+func newCursor(last bson.Raw, sorted bool) (string, error) {
+	objectID, ok := last.Lookup("_id").ObjectIDOK()
+	if !ok {
+		return "", fmt.Errorf("error while creating cursor, _id of the last document is not an ObjectID")
+	}
+
+	id := objectID.Hex()
+	if !sorted {
+		return id, nil
+	}
+
+	var value float64
+	sortValue := last.Lookup("_sort")
+	switch sortValue.Type {
+	case bsontype.Int32:
+		value = float64(sortValue.Int32())
+	case bsontype.Int64:
+		value = float64(sortValue.Int64())
+	case bsontype.Double:
+		value = sortValue.Double()
+	}
+
+	return fmt.Sprintf("%s_%s", strconv.FormatFloat(value, 'f', -1, 64), id), nil
+}
+
+func newCursorFilter(page Page) (bson.M, error) {
+	idHex, sortValue := page.Cursor, ""
+	if page.SortBy != "" {
+		i := strings.LastIndex(page.Cursor, "_")
+		if i < 0 {
+			return nil, ErrInvalidCursor
+		}
+		sortValue, idHex = page.Cursor[:i], page.Cursor[i+1:]
+	}
+
+	id, err := primitive.ObjectIDFromHex(idHex)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if page.SortBy == "" {
+		return bson.M{"_id": bson.M{"$lt": id}}, nil
+	}
+
+	value, err := strconv.ParseFloat(sortValue, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	after := bson.M{
+		"$or": bson.A{
+			bson.M{"_sort": bson.M{"$lt": value}},
+			bson.M{"_sort": value, "_id": bson.M{"$lt": id}},
+		},
+	}
+	return after, nil
+}
+
 func (db *MongoDB) find(
 	collectionName string,
 	filter map[string]interface{},
@@ -373,6 +689,25 @@ func (db *MongoDB) find(
 	return err
 }
 
+func (db *MongoDB) Count(collectionName string, filter map[string]interface{}) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(db.Timeout)*time.Second)
+	defer cancel()
+
+	db.Logger.WithFields(log.Fields{
+		"collection": collectionName,
+		"filter":     filter,
+	}).Debug("Counting documents in database.")
+	collection := db.Collection(collectionName)
+
+	count, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		db.Logger.Error(err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (db *MongoDB) Delete(collectionName string, filter map[string]interface{}) (bool, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(db.Timeout)*time.Second)
 	defer cancel()
@@ -423,6 +758,26 @@ func (db *MongoDB) DeleteAll(collectionName string, filter map[string]interface{
 	return deleted, nil
 }
 
+// DeleteMany works the same as DeleteAll but returns the number of documents that were deleted.
+func (db *MongoDB) DeleteMany(collectionName string, filter map[string]interface{}) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(db.Timeout)*time.Second)
+	defer cancel()
+
+	db.Logger.WithFields(log.Fields{
+		"collection": collectionName,
+		"filter":     filter,
+	}).Debug("Deleting documents from database.")
+	collection := db.Collection(collectionName)
+
+	result, err := collection.DeleteMany(ctx, filter)
+	if err != nil {
+		db.Logger.Error(err)
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
+
 func (db *MongoDB) RemoveCollection(collectionName string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(db.Timeout)*time.Second)
 	defer cancel()
@@ -483,6 +838,25 @@ func (db *MongoDB) RemoveObject(
 	return updated, err
 }
 
+// Increment atomically adds the amount to a number field of the first document matching the filter, a negative amount
+// decrements it. A missing field is treated as zero.
+func (db *MongoDB) Increment(
+	collectionName string,
+	filter map[string]interface{},
+	fieldName string,
+	amount int,
+) (bool, error) {
+	db.Logger.WithFields(log.Fields{
+		"collection": collectionName,
+		"filter":     filter,
+		"field":      fieldName,
+		"amount":     amount,
+	}).Debug("Incrementing field of entry in the database.")
+
+	updated, err := db.modifyEntry(collectionName, filter, bson.M{fieldName: amount}, "$inc")
+	return updated, err
+}
+
 func (db *MongoDB) AppendToList(
 	collectionName string,
 	filter map[string]interface{},
@@ -538,6 +912,60 @@ func (db *MongoDB) modifyEntry(
 	return updated, nil
 }
 
+// EnsureIndexes creates the indexes the API needs, indexes that already exist are left as they are.
+func (db *MongoDB) EnsureIndexes() error {
+	for _, index := range Indexes {
+		err := db.createIndex(index.Collection, index.Field, index.Unique)
+		if err != nil {
+			return fmt.Errorf("error while creating index for %s %w", index.Collection, err)
+		}
+	}
+
+	return nil
+}
+
+// namespaceNotFound is the code of the error MongoDB returns when listing the indexes of a collection that doesn't exist.
+const namespaceNotFound = 26
+
+// GetIndexes gets the fields of each index of a collection, fields of compound indexes are separated by commas. A
+// collection that doesn't exist has no indexes.
+func (db *MongoDB) GetIndexes(collectionName string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(db.Timeout)*time.Second)
+	defer cancel()
+
+	db.Logger.WithFields(log.Fields{
+		"collection": collectionName,
+	}).Debug("Getting indexes from database.")
+
+	cursor, err := db.Collection(collectionName).Indexes().List(ctx)
+	if commandErr, ok := err.(mongo.CommandError); ok && commandErr.Code == namespaceNotFound {
+		return []string{}, nil
+	} else if err != nil {
+		db.Logger.Errorf("failed to get indexes: %v", err)
+		return nil, err
+	}
+
+	indexes := []struct {
+		Key bson.D `bson:"key"`
+	}{}
+	err = cursor.All(ctx, &indexes)
+	if err != nil {
+		db.Logger.Errorf("failed to transform indexes: %v", err)
+		return nil, err
+	}
+
+	fields := []string{}
+	for _, index := range indexes {
+		keys := []string{}
+		for _, key := range index.Key {
+			keys = append(keys, key.Key)
+		}
+		fields = append(fields, strings.Join(keys, ","))
+	}
+
+	return fields, nil
+}
+
 func (db *MongoDB) createIndex(collectionName string, field string, unique bool) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(db.Timeout)*time.Second)
 	defer cancel()
@@ -0,0 +1,216 @@
+package drawlosseum
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/games"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/questions"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/story"
+)
+
+const Name = "drawlosseum"
+
+const (
+	// MaxSegments is the most line segments a single drawing can have.
+	MaxSegments = 5000
+	// MaxPayloadSize is the largest a drawing can be in bytes, when encoded as JSON.
+	MaxPayloadSize = 512 * 1024
+)
+
+// Canvas is the size of the canvas players draw on, it is centred on the origin.
+type Canvas struct {
+	Width  int
+	Height int
+}
+
+func Game(canvas Canvas) games.GameDefinition {
+	return games.GameDefinition{
+		Name:      Name,
+		Questions: Questions{},
+		Story:     Story{Canvas: canvas},
+	}
+}
+
+type Questions struct{}
+
+func (d Questions) DefaultRules() questions.GameRules {
+	return questions.GameRules{
+		Rounds: []questions.RoundRules{
+			{Name: "drawing"},
+		},
+	}
+}
+
+// ValidateQuestion allows questions without a round, as Drawlosseum only has a single round. Questions with a round
+// must use one of the rounds of the game.
+func (d Questions) ValidateQuestion(question questions.QuestionIn, rules questions.GameRules) error {
+	if question.Round == "" {
+		return nil
+	}
+
+	return rules.ValidateQuestion(question)
+}
+
+// Story validates that drawings fit on the canvas.
+type Story struct {
+	Canvas Canvas
+}
+
+func (d Story) NewAnswers() story.StoryAnswerType {
+	return &story.DrawlosseumAnswers{}
+}
+
+func (d Story) NewStoryOut(s story.Story) (story.StoryInOut, error) {
+	storyAnswers, ok := s.Answers.(*story.DrawlosseumAnswers)
+	if !ok {
+		return story.StoryInOut{}, errors.Errorf("invalid answer for Drawlosseum")
+	}
+	answers := d.newAnswersOut(storyAnswers)
+	newStory := story.StoryInOut{
+		Question: s.Question,
+		Nickname: s.Nickname,
+		StoryAnswersInOut: story.StoryAnswersInOut{
+			Drawlosseum: answers,
+		},
+	}
+	return newStory, nil
+}
+
+// NewTranscript includes the drawing, rendered as an SVG image.
+func (d Story) NewTranscript(s story.Story, options story.ImageOptions) (story.Transcript, error) {
+	drawing, err := d.RenderSVG(s, options)
+	if err != nil {
+		return story.Transcript{}, err
+	}
+
+	transcript := story.Transcript{
+		Question: s.Question,
+		Nickname: s.Nickname,
+		Drawing:  drawing,
+	}
+	return transcript, nil
+}
+
+func (d Story) newAnswersOut(storyAnswers *story.DrawlosseumAnswers) story.DrawlosseumAnswersInOut {
+	var answers story.DrawlosseumAnswersInOut
+	for _, storyAnswer := range *storyAnswers {
+		answers = append(answers, storyAnswer)
+	}
+
+	return answers
+}
+
+// NewStory allows a story without answers if it is in progress, as the answers can be appended later.
+func (d Story) NewStory(s story.StoryInOut) (story.Story, error) {
+	answers := story.DrawlosseumAnswers{}
+	if !s.InProgress || len(s.StoryAnswersInOut.Drawlosseum) > 0 {
+		var err error
+		answers, err = d.newAnswers(s.StoryAnswersInOut.Drawlosseum)
+		if err != nil {
+			return story.Story{}, err
+		}
+	}
+
+	newStory := story.Story{
+		Question: s.Question,
+		Nickname: s.Nickname,
+		Answers:  answers,
+	}
+	return newStory, nil
+}
+
+func (d Story) newAnswers(storyAnswers story.DrawlosseumAnswersInOut) (story.DrawlosseumAnswers, error) {
+	var answers story.DrawlosseumAnswers
+	if len(storyAnswers) == 0 {
+		return story.DrawlosseumAnswers{}, errors.BadRequestf("no answers in the story.")
+	}
+
+	err := validateSize(storyAnswers)
+	if err != nil {
+		return story.DrawlosseumAnswers{}, err
+	}
+
+	var invalidSegments []string
+	for i, storyAnswer := range storyAnswers {
+		if reason := d.Canvas.validateSegment(storyAnswer); reason != "" {
+			invalidSegments = append(invalidSegments, fmt.Sprintf("%d (%s)", i, reason))
+			continue
+		}
+		answers = append(answers, storyAnswer)
+	}
+
+	if len(invalidSegments) > 0 {
+		return story.DrawlosseumAnswers{}, errors.BadRequestf(
+			"invalid segments at indexes %s.",
+			strings.Join(invalidSegments, ", "),
+		)
+	}
+
+	return answers, nil
+}
+
+// AppendAnswers adds segments to the drawing, the size limits apply to the whole drawing.
+func (d Story) AppendAnswers(s story.Story, storyAnswers story.StoryAnswersInOut) (story.Story, error) {
+	existingAnswers, ok := s.Answers.(*story.DrawlosseumAnswers)
+	if !ok {
+		return story.Story{}, errors.Errorf("invalid answer for Drawlosseum")
+	}
+
+	newAnswers, err := d.newAnswers(storyAnswers.Drawlosseum)
+	if err != nil {
+		return story.Story{}, err
+	}
+
+	answers := append(story.DrawlosseumAnswers{}, *existingAnswers...)
+	answers = append(answers, newAnswers...)
+	err = validateSize(story.DrawlosseumAnswersInOut(answers))
+	if err != nil {
+		return story.Story{}, err
+	}
+
+	s.Answers = &answers
+	return s, nil
+}
+
+func validateSize(storyAnswers story.DrawlosseumAnswersInOut) error {
+	if len(storyAnswers) > MaxSegments {
+		return errors.BadRequestf("drawing has %d segments, at most %d are allowed.", len(storyAnswers), MaxSegments)
+	}
+
+	payload, err := json.Marshal(storyAnswers)
+	if err != nil {
+		return errors.Errorf("failed to encode drawing %v", err)
+	} else if len(payload) > MaxPayloadSize {
+		return errors.BadRequestf("drawing is %d bytes, at most %d bytes are allowed.", len(payload), MaxPayloadSize)
+	}
+
+	return nil
+}
+
+// validateSegment returns why the segment is invalid, or an empty string if it is valid.
+func (c Canvas) validateSegment(segment story.CaertsianCoordinateColor) string {
+	var reasons []string
+	if !c.contains(segment.Start) {
+		reasons = append(reasons, "start is outside the canvas")
+	}
+
+	if !c.contains(segment.End) {
+		reasons = append(reasons, "end is outside the canvas")
+	}
+
+	if _, ok := parseColor(segment.Color); !ok {
+		reasons = append(reasons, fmt.Sprintf("invalid color %q", segment.Color))
+	}
+
+	return strings.Join(reasons, ", ")
+}
+
+func (c Canvas) contains(point story.DrawingPoint) bool {
+	halfWidth, halfHeight := float64(c.Width)/2, float64(c.Height)/2
+	return float64(point.X) >= -halfWidth && float64(point.X) <= halfWidth &&
+		float64(point.Y) >= -halfHeight && float64(point.Y) <= halfHeight
+}
@@ -0,0 +1,202 @@
+package drawlosseum
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/story"
+)
+
+// line is a segment of the drawing, transformed onto the canvas.
+type line struct {
+	x1, y1, x2, y2 float64
+	color          color.RGBA
+}
+
+// RenderSVG draws the answers of the story as an SVG image, see newLines for how the drawing is placed on the canvas.
+func (d Story) RenderSVG(s story.Story, options story.ImageOptions) ([]byte, error) {
+	lines, err := newLines(s, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var svg strings.Builder
+	fmt.Fprintf(
+		&svg,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`,
+		options.Width,
+		options.Height,
+		options.Width,
+		options.Height,
+	)
+
+	background, transparent, err := parseBackground(options.Background)
+	if err != nil {
+		return nil, err
+	} else if !transparent {
+		fmt.Fprintf(&svg, `<rect width="100%%" height="100%%" fill="%s"/>`, toHex(background))
+	}
+
+	for _, l := range lines {
+		fmt.Fprintf(
+			&svg,
+			`<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s" stroke-width="%s" stroke-linecap="round"/>`,
+			formatFloat(l.x1),
+			formatFloat(l.y1),
+			formatFloat(l.x2),
+			formatFloat(l.y2),
+			toHex(l.color),
+			formatFloat(options.StrokeWidth),
+		)
+	}
+
+	svg.WriteString("</svg>")
+	return []byte(svg.String()), nil
+}
+
+// RenderPNG draws the answers of the story as a PNG image, it draws exactly the same lines as RenderSVG.
+func (d Story) RenderPNG(s story.Story, options story.ImageOptions) ([]byte, error) {
+	lines, err := newLines(s, options)
+	if err != nil {
+		return nil, err
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, options.Width, options.Height))
+	background, transparent, err := parseBackground(options.Background)
+	if err != nil {
+		return nil, err
+	} else if !transparent {
+		draw.Draw(canvas, canvas.Bounds(), &image.Uniform{C: background}, image.Point{}, draw.Src)
+	}
+
+	for _, l := range lines {
+		drawLine(canvas, l, options.StrokeWidth/2)
+	}
+
+	var buffer bytes.Buffer
+	err = png.Encode(&buffer, canvas)
+	if err != nil {
+		return nil, errors.Errorf("failed to encode drawing %v", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// newLines scales the drawing to fit the canvas, keeping its aspect ratio, and centres it. The stroke width is used
+// as padding so lines at the edge of the drawing aren't cut off. The answers use Cartesian coordinates, so the y-axis
+// is flipped as it points down on the canvas.
+func newLines(s story.Story, options story.ImageOptions) ([]line, error) {
+	answers, ok := s.Answers.(*story.DrawlosseumAnswers)
+	if !ok {
+		return nil, errors.Errorf("invalid answer for Drawlosseum")
+	}
+
+	if len(*answers) == 0 {
+		return []line{}, nil
+	}
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, answer := range *answers {
+		for _, point := range []story.DrawingPoint{answer.Start, answer.End} {
+			minX, maxX = math.Min(minX, float64(point.X)), math.Max(maxX, float64(point.X))
+			minY, maxY = math.Min(minY, float64(point.Y)), math.Max(maxY, float64(point.Y))
+		}
+	}
+
+	padding := options.StrokeWidth
+	width, height := float64(options.Width)-2*padding, float64(options.Height)-2*padding
+	scale := 1.0
+	if maxX > minX || maxY > minY {
+		scale = math.Min(width/math.Max(maxX-minX, 1), height/math.Max(maxY-minY, 1))
+	}
+
+	centreX, centreY := (minX+maxX)/2, (minY+maxY)/2
+	transform := func(point story.DrawingPoint) (float64, float64) {
+		x := float64(options.Width)/2 + (float64(point.X)-centreX)*scale
+		y := float64(options.Height)/2 - (float64(point.Y)-centreY)*scale
+		return x, y
+	}
+
+	lines := []line{}
+	for _, answer := range *answers {
+		l := line{color: lineColor(answer.Color)}
+		l.x1, l.y1 = transform(answer.Start)
+		l.x2, l.y2 = transform(answer.End)
+		lines = append(lines, l)
+	}
+
+	return lines, nil
+}
+
+// drawLine fills every pixel whose centre is within the radius of the line, which gives the line round caps.
+func drawLine(canvas *image.RGBA, l line, radius float64) {
+	bounds := image.Rect(
+		int(math.Floor(math.Min(l.x1, l.x2)-radius)),
+		int(math.Floor(math.Min(l.y1, l.y2)-radius)),
+		int(math.Ceil(math.Max(l.x1, l.x2)+radius))+1,
+		int(math.Ceil(math.Max(l.y1, l.y2)+radius))+1,
+	).Intersect(canvas.Bounds())
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if distanceToLine(float64(x)+0.5, float64(y)+0.5, l) <= radius {
+				canvas.SetRGBA(x, y, l.color)
+			}
+		}
+	}
+}
+
+func distanceToLine(x float64, y float64, l line) float64 {
+	dx, dy := l.x2-l.x1, l.y2-l.y1
+	lengthSquared := dx*dx + dy*dy
+
+	t := 0.0
+	if lengthSquared > 0 {
+		t = math.Max(0, math.Min(1, ((x-l.x1)*dx+(y-l.y1)*dy)/lengthSquared))
+	}
+
+	return math.Hypot(x-(l.x1+t*dx), y-(l.y1+t*dy))
+}
+
+// parseBackground parses the background colour of the canvas, which can also be empty or `transparent`.
+func parseBackground(background string) (color.RGBA, bool, error) {
+	if background == "" || background == "transparent" {
+		return color.RGBA{}, true, nil
+	}
+
+	parsed, ok := parseColor(background)
+	if !ok {
+		return color.RGBA{}, false, errors.Errorf("invalid background colour %s", background)
+	}
+
+	return parsed, false, nil
+}
+
+// lineColor gets the colour of a line, stories added before colours were validated may have invalid colours, they
+// are drawn in black rather than failing the whole drawing.
+func lineColor(colorString string) color.RGBA {
+	parsed, ok := parseColor(colorString)
+	if !ok {
+		return color.RGBA{A: 255}
+	}
+
+	return parsed
+}
+
+func toHex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
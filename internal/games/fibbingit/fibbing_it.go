@@ -0,0 +1,157 @@
+package fibbingit
+
+import (
+	"github.com/juju/errors"
+
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/games"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/questions"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/story"
+)
+
+const Name = "fibbing_it"
+
+func Game() games.GameDefinition {
+	return games.GameDefinition{
+		Name:      Name,
+		Questions: Questions{},
+		Story:     Story{},
+	}
+}
+
+type Questions struct{}
+
+func (f Questions) DefaultRules() questions.GameRules {
+	groupTypes := []string{"answer", "question"}
+
+	return questions.GameRules{
+		Rounds: []questions.RoundRules{
+			{Name: "opinion", HasGroups: true, GroupTypes: groupTypes},
+			{Name: "likely"},
+			{Name: "free_form", HasGroups: true, GroupTypes: groupTypes},
+		},
+	}
+}
+
+func (f Questions) ValidateQuestion(question questions.QuestionIn, rules questions.GameRules) error {
+	return rules.ValidateQuestion(question)
+}
+
+type Story struct{}
+
+func (f Story) NewAnswers() story.StoryAnswerType {
+	return &story.FibbingItAnswers{}
+}
+
+func (f Story) NewStoryOut(s story.Story) (story.StoryInOut, error) {
+	storyAnswer, ok := s.Answers.(*story.FibbingItAnswers)
+	if !ok {
+		return story.StoryInOut{}, errors.Errorf("invalid answer for Fibbing It")
+	}
+
+	answers := f.newAnswersOut(storyAnswer)
+	newStory := story.StoryInOut{
+		Question: s.Question,
+		Round:    s.Round,
+		StoryAnswersInOut: story.StoryAnswersInOut{
+			FibbingIt: answers,
+		},
+	}
+	return newStory, nil
+}
+
+func (f Story) NewTranscript(s story.Story, _ story.ImageOptions) (story.Transcript, error) {
+	storyAnswers, ok := s.Answers.(*story.FibbingItAnswers)
+	if !ok {
+		return story.Transcript{}, errors.Errorf("invalid answer for Fibbing It")
+	}
+
+	transcript := story.Transcript{Question: s.Question, Round: s.Round}
+	for _, answer := range *storyAnswers {
+		transcript.Entries = append(transcript.Entries, story.TranscriptEntry{
+			Nickname: answer.Nickname,
+			Answer:   answer.Answer,
+		})
+	}
+
+	return transcript, nil
+}
+
+func (f Story) newAnswersOut(storyAnswers *story.FibbingItAnswers) story.FibbingItAnswersInOut {
+	var answers story.FibbingItAnswersInOut
+	for _, storyAnswer := range *storyAnswers {
+		answer := story.FibbingItAnswerInOut(storyAnswer)
+		answers = append(answers, answer)
+	}
+
+	return answers
+}
+
+// NewStory allows a story without answers if it is in progress, as the answers can be appended later.
+func (f Story) NewStory(s story.StoryInOut) (story.Story, error) {
+	answers := story.FibbingItAnswers{}
+	if !s.InProgress || len(s.StoryAnswersInOut.FibbingIt) > 0 {
+		var err error
+		answers, err = f.newAnswers(s.StoryAnswersInOut.FibbingIt)
+		if err != nil {
+			return story.Story{}, err
+		}
+	}
+
+	newStory := story.Story{
+		Question: s.Question,
+		Round:    s.Round,
+		Answers:  answers,
+	}
+	return newStory, nil
+}
+
+func (f Story) newAnswers(storyAnswers story.FibbingItAnswersInOut) (story.FibbingItAnswers, error) {
+	var answers story.FibbingItAnswers
+	if len(storyAnswers) == 0 {
+		return story.FibbingItAnswers{}, errors.BadRequestf("no answers in the story.")
+	}
+
+	for _, storyAnswer := range storyAnswers {
+		answer := story.FibbingItAnswer(storyAnswer)
+		if hasAnswered(answers, answer.Nickname) {
+			return story.FibbingItAnswers{}, errors.BadRequestf("player %s has answered more than once.", answer.Nickname)
+		}
+		answers = append(answers, answer)
+	}
+
+	return answers, nil
+}
+
+// AppendAnswers adds the answers of players who haven't answered yet, each player can only answer once.
+func (f Story) AppendAnswers(s story.Story, storyAnswers story.StoryAnswersInOut) (story.Story, error) {
+	existingAnswers, ok := s.Answers.(*story.FibbingItAnswers)
+	if !ok {
+		return story.Story{}, errors.Errorf("invalid answer for Fibbing It")
+	}
+
+	newAnswers, err := f.newAnswers(storyAnswers.FibbingIt)
+	if err != nil {
+		return story.Story{}, err
+	}
+
+	answers := append(story.FibbingItAnswers{}, *existingAnswers...)
+	for _, newAnswer := range newAnswers {
+		if hasAnswered(answers, newAnswer.Nickname) {
+			return story.Story{}, errors.BadRequestf("player %s has already answered.", newAnswer.Nickname)
+		}
+		answers = append(answers, newAnswer)
+	}
+
+	s.Answers = &answers
+	return s, nil
+}
+
+func hasAnswered(answers story.FibbingItAnswers, nickname string) bool {
+	for _, answer := range answers {
+		if answer.Nickname == nickname {
+			return true
+		}
+	}
+
+	return false
+}
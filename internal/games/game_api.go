@@ -8,6 +8,7 @@ import (
 	"gitlab.com/banter-bus/banter-bus-management-api/internal"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/core"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/core/database"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/questions"
 )
 
 type GameAPI struct {
@@ -44,14 +45,21 @@ func (env *GameAPI) AddGame(_ *gin.Context, game *GameIn) (struct{}, error) {
 
 func (env *GameAPI) GetGames(_ *gin.Context, params *ListGameParams) ([]string, error) {
 	gameLogger := env.Logger.WithFields(log.Fields{
-		"games": params.Games,
+		"games":    params.Games,
+		"language": params.Language,
+		"region":   params.Region,
 	})
 	gameLogger.Debug("Trying to get all games.")
 
 	enabled := internal.GetEnabledBool(params.Games)
 	g := GameService{DB: env.DB}
-	games, err := g.GetAll(enabled)
-	if err != nil {
+	games, err := g.GetAll(enabled, params.Language, params.Region)
+	if errors.IsBadRequest(err) {
+		gameLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Invalid language or region.")
+		return []string{}, err
+	} else if err != nil {
 		gameLogger.WithFields(log.Fields{
 			"err": err,
 		}).Error("Failed to get game.")
@@ -81,58 +89,237 @@ func (env *GameAPI) GetGame(_ *gin.Context, params *internal.GameParams) (*GameO
 		return &GameOut{}, errors.NotFoundf("The game %s", params.GameName)
 	}
 
-	gameObj := &GameOut{
+	return newGameOut(game), nil
+}
+
+func newGameOut(game *Game) *GameOut {
+	return &GameOut{
 		Name:     game.Name,
 		RulesURL: game.RulesURL,
 		Enabled:  *game.Enabled,
+		GameMetadataOut: GameMetadataOut{
+			MinPlayers:      game.MinPlayers,
+			MaxPlayers:      game.MaxPlayers,
+			DurationMinutes: game.DurationMinutes,
+			DisplayNames:    game.DisplayNames,
+			Descriptions:    game.Descriptions,
+			Tags:            game.Tags,
+			Icon:            game.Icon,
+			Languages:       game.Languages,
+			Regions:         game.Regions,
+			RetentionDays:   game.RetentionDays,
+		},
 	}
-	return gameObj, nil
 }
 
-func (env *GameAPI) RemoveGame(_ *gin.Context, params *internal.GameParams) (struct{}, error) {
+func (env *GameAPI) UpdateGameMetadata(_ *gin.Context, input *UpdateGameMetadataInput) (*GameOut, error) {
 	gameLogger := env.Logger.WithFields(log.Fields{
-		"game_name": params.GameName,
+		"game_name": input.GameName,
+		"metadata":  input.GameMetadataIn,
+	})
+	gameLogger.Debug("Trying to update game metadata.")
+
+	gameService := GameService{DB: env.DB, Name: input.GameName}
+	game, err := gameService.UpdateMetadata(input.GameMetadataIn)
+	if err != nil {
+		gameLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Failed to update game metadata.")
+		return &GameOut{}, err
+	}
+
+	return newGameOut(game), nil
+}
+
+func (env *GameAPI) RemoveGame(_ *gin.Context, input *RemoveGameInput) (GameRemovalOut, error) {
+	gameLogger := env.Logger.WithFields(log.Fields{
+		"game_name": input.GameName,
+		"dry_run":   input.DryRun,
 	})
 	gameLogger.Debug("Removing game.")
 
-	var emptyResponse struct{}
+	var (
+		gameService  = GameService{DB: env.DB, Name: input.GameName}
+		removalToken RemovalToken
+		err          error
+	)
+
+	if input.DryRun {
+		removalToken, err = gameService.PreviewRemove()
+	} else {
+		removalToken.Removal, err = gameService.Remove(input.ConfirmationToken)
+	}
 
-	gameService := GameService{DB: env.DB, Name: params.GameName}
-	err := gameService.Remove()
 	if errors.IsNotFound(err) {
 		gameLogger.WithFields(log.Fields{
 			"err": err,
 		}).Warn("Game does not exist.")
-
-		return emptyResponse, err
+		return GameRemovalOut{}, err
+	} else if errors.IsBadRequest(err) {
+		gameLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Invalid confirmation token.")
+		return GameRemovalOut{}, err
 	} else if err != nil {
 		gameLogger.WithFields(log.Fields{
 			"err": err,
 		}).Error("Failed to remove game.")
+		return GameRemovalOut{}, err
+	}
+
+	removalOut := GameRemovalOut{
+		DryRun:       input.DryRun,
+		Questions:    removalToken.Removal.Questions,
+		Translations: removalToken.Removal.Translations,
+		Stories:      removalToken.Removal.Stories,
+	}
+	if input.DryRun {
+		removalOut.ConfirmationToken = removalToken.Token
+		removalOut.ExpiresAt = &removalToken.ExpiresAt
+	}
+
+	return removalOut, nil
+}
+
+func (env *GameAPI) GetGameStats(_ *gin.Context, params *internal.GameParams) (GameStatsOut, error) {
+	gameLogger := env.Logger.WithFields(log.Fields{
+		"game_name": params.GameName,
+	})
+	gameLogger.Debug("Getting game stats.")
+
+	gameService := GameService{DB: env.DB, Name: params.GameName}
+	stats, err := gameService.GetStats()
+	if errors.IsNotFound(err) {
+		gameLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Game does not exist.")
+		return GameStatsOut{}, err
+	} else if err != nil {
+		gameLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("Failed to get game stats.")
+		return GameStatsOut{}, err
+	}
+
+	statsOut := GameStatsOut{
+		Questions: QuestionStatsOut{
+			Total:      stats.Questions,
+			Enabled:    stats.EnabledQuestions,
+			Disabled:   stats.DisabledQuestions,
+			ByRound:    stats.QuestionsByRound,
+			ByGroup:    stats.QuestionsByGroup,
+			ByLanguage: stats.QuestionsByLanguage,
+			Stories:    stats.StoriesByQuestion,
+		},
+		Stories:             stats.Stories,
+		LatestStory:         stats.LatestStory,
+		TranslationCoverage: stats.TranslationCoverage,
+	}
+	return statsOut, nil
+}
+
+func (env *GameAPI) GetGameRules(_ *gin.Context, params *internal.GameParams) (GameRulesInOut, error) {
+	gameLogger := env.Logger.WithFields(log.Fields{
+		"game_name": params.GameName,
+	})
+	gameLogger.Debug("Trying to get game rules.")
+
+	gameService := GameService{DB: env.DB, Name: params.GameName}
+	rules, err := gameService.GetRules()
+	if err != nil {
+		gameLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Failed to get game rules.")
+		return GameRulesInOut{}, err
+	}
+
+	rounds := []RoundRulesInOut{}
+	for _, round := range rules.Rounds {
+		rounds = append(rounds, RoundRulesInOut(round))
+	}
+
+	return GameRulesInOut{Rounds: rounds}, nil
+}
+
+func (env *GameAPI) GetRounds(_ *gin.Context, params *internal.GameParams) ([]RoundOut, error) {
+	gameLogger := env.Logger.WithFields(log.Fields{
+		"game_name": params.GameName,
+	})
+	gameLogger.Debug("Trying to get game rounds.")
+
+	gameService := GameService{DB: env.DB, Name: params.GameName}
+	rules, err := gameService.GetRules()
+	if err != nil {
+		gameLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Failed to get game rounds.")
+		return []RoundOut{}, err
+	}
+
+	rounds := []RoundOut{}
+	for _, round := range rules.Rounds {
+		rounds = append(rounds, RoundOut{
+			RoundRulesInOut: RoundRulesInOut(round),
+			Examples:        round.Examples(),
+		})
+	}
+
+	return rounds, nil
+}
+
+func (env *GameAPI) UpdateGameRules(_ *gin.Context, input *UpdateGameRulesInput) (struct{}, error) {
+	gameLogger := env.Logger.WithFields(log.Fields{
+		"game_name": input.GameName,
+		"rules":     input.GameRulesInOut,
+	})
+	gameLogger.Debug("Trying to update game rules.")
+
+	var emptyResponse struct{}
+	rounds := []questions.RoundRules{}
+	for _, round := range input.Rounds {
+		rounds = append(rounds, questions.RoundRules(round))
+	}
+
+	gameService := GameService{DB: env.DB, Name: input.GameName}
+	err := gameService.UpdateRules(questions.GameRules{Rounds: rounds})
+	if err != nil {
+		gameLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Failed to update game rules.")
 		return emptyResponse, err
 	}
 
 	return emptyResponse, nil
 }
 
-func (env *GameAPI) EnableGame(_ *gin.Context, params *internal.GameParams) (struct{}, error) {
-	return env.updateEnableGameState(params.GameName, true)
+func (env *GameAPI) EnableGame(_ *gin.Context, input *UpdateEnableInput) (struct{}, error) {
+	return env.updateEnableGameState(input.GameName, input.Language, true)
 }
 
-func (env *GameAPI) DisableGame(_ *gin.Context, params *internal.GameParams) (struct{}, error) {
-	return env.updateEnableGameState(params.GameName, false)
+func (env *GameAPI) DisableGame(_ *gin.Context, input *UpdateEnableInput) (struct{}, error) {
+	return env.updateEnableGameState(input.GameName, input.Language, false)
 }
 
-func (env *GameAPI) updateEnableGameState(name string, enable bool) (struct{}, error) {
+func (env *GameAPI) updateEnableGameState(name string, languageCode string, enable bool) (struct{}, error) {
 	gameLogger := env.Logger.WithFields(log.Fields{
 		"game_name": name,
+		"language":  languageCode,
 		"enable":    enable,
 	})
 	gameLogger.Debug("Trying to update enable state.")
 
-	var emptyResponse struct{}
-	gameService := GameService{DB: env.DB, Name: name}
-	updated, err := gameService.UpdateEnable(enable)
+	var (
+		emptyResponse struct{}
+		gameService   = GameService{DB: env.DB, Name: name}
+		updated       bool
+		err           error
+	)
+
+	if languageCode == "" {
+		updated, err = gameService.UpdateEnable(enable)
+	} else {
+		updated, err = gameService.UpdateLanguageEnable(languageCode, enable)
+	}
 
 	if err != nil || !updated {
 		gameLogger.WithFields(log.Fields{
@@ -1,6 +1,11 @@
 package games
 
-import "gitlab.com/banter-bus/banter-bus-management-api/internal"
+import (
+	"time"
+
+	"gitlab.com/banter-bus/banter-bus-management-api/internal"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/questions"
+)
 
 type GameIn struct {
 	Name     string `json:"name"      description:"The name of the new game "         example:"quibly"              validate:"required"`
@@ -11,10 +16,103 @@ type GameOut struct {
 	Name     string `json:"name"      description:"The name of the new game."           example:"quibly"`
 	RulesURL string `json:"rules_url" description:"The URL to the rules of the game."   example:"gitlab.com/rules.md"`
 	Enabled  bool   `json:"enabled"   description:"If set to true the game is enabled." example:"false"`
+	GameMetadataOut
+}
+
+type GameMetadataOut struct {
+	MinPlayers      int               `json:"min_players,omitempty"      description:"The minimum number of players needed to play the game."        example:"3"`
+	MaxPlayers      int               `json:"max_players,omitempty"      description:"The maximum number of players that can play the game."         example:"8"`
+	DurationMinutes int               `json:"duration_minutes,omitempty" description:"The estimated length of a game in minutes."                    example:"20"`
+	DisplayNames    map[string]string `json:"display_names,omitempty"    description:"The name of the game to show players, keyed by language code."`
+	Descriptions    map[string]string `json:"descriptions,omitempty"     description:"A short description of the game, keyed by language code."`
+	Tags            []string          `json:"tags,omitempty"             description:"Tags used to categorise the game."`
+	Icon            string            `json:"icon,omitempty"             description:"A reference to the icon of the game."                         example:"icons/quibly.svg"`
+	Languages       []string          `json:"languages,omitempty"        description:"The languages the game is available in, all languages if empty."`
+	Regions         []string          `json:"regions,omitempty"          description:"The regions the game is available in, all regions if empty."`
+	RetentionDays   int               `json:"retention_days,omitempty"   description:"The number of days stories are kept for, forever if 0."       example:"30"`
+}
+
+type GameMetadataIn struct {
+	MinPlayers      *int               `json:"min_players,omitempty"      description:"The minimum number of players needed to play the game."        example:"3"`
+	MaxPlayers      *int               `json:"max_players,omitempty"      description:"The maximum number of players that can play the game."         example:"8"`
+	DurationMinutes *int               `json:"duration_minutes,omitempty" description:"The estimated length of a game in minutes."                    example:"20"`
+	DisplayNames    *map[string]string `json:"display_names,omitempty"    description:"The name of the game to show players, keyed by language code."`
+	Descriptions    *map[string]string `json:"descriptions,omitempty"     description:"A short description of the game, keyed by language code."`
+	Tags            *[]string          `json:"tags,omitempty"             description:"Tags used to categorise the game."`
+	Icon            *string            `json:"icon,omitempty"             description:"A reference to the icon of the game."                         example:"icons/quibly.svg"`
+	Languages       *[]string          `json:"languages,omitempty"        description:"The languages the game is available in, all languages if empty."`
+	Regions         *[]string          `json:"regions,omitempty"          description:"The regions the game is available in, all regions if empty."`
+	RetentionDays   *int               `json:"retention_days,omitempty"   description:"The number of days stories are kept for, forever if 0."       example:"30"`
+}
+
+type UpdateGameMetadataInput struct {
+	internal.GameParams
+	GameMetadataIn
+}
+
+type GameRulesInOut struct {
+	Rounds []RoundRulesInOut `json:"rounds" description:"The rounds of the game." validate:"required"`
+}
+
+type RoundRulesInOut struct {
+	Name             string   `json:"name"                         description:"The name of the round."                                 example:"opinion"         validate:"required"`
+	HasGroups        bool     `json:"has_groups"                   description:"If set to true questions in this round can be grouped." example:"true"`
+	GroupTypes       []string `json:"group_types,omitempty"        description:"The valid types of content in a group, question or answer."`
+	MinContentLength int      `json:"min_content_length,omitempty" description:"The minimum length of a question, 0 for no limit."      example:"0"`
+	MaxContentLength int      `json:"max_content_length,omitempty" description:"The maximum length of a question, 0 for no limit."      example:"200"`
+}
+
+type RoundOut struct {
+	RoundRulesInOut
+	Examples []questions.QuestionIn `json:"examples" description:"Example questions that are valid for this round."`
+}
+
+type UpdateGameRulesInput struct {
+	internal.GameParams
+	GameRulesInOut
+}
+
+type RemoveGameInput struct {
+	internal.GameParams
+	DryRun            bool   `query:"dry_run"            description:"If set to true nothing is removed, instead the counts and confirmation token are returned." default:"false"`
+	ConfirmationToken string `query:"confirmation_token" description:"The confirmation token from the dry run, required to remove the game."`
+}
+
+type GameRemovalOut struct {
+	DryRun            bool       `json:"dry_run"                      description:"If set to true nothing was removed."                        example:"true"`
+	Questions         int        `json:"questions"                    description:"The number of questions removed with the game."             example:"10"`
+	Translations      int        `json:"translations"                 description:"The number of question translations removed with the game." example:"25"`
+	Stories           int        `json:"stories"                      description:"The number of stories removed with the game."               example:"3"`
+	ConfirmationToken string     `json:"confirmation_token,omitempty" description:"The token to pass to remove the game, only set on a dry run." example:"Yd3m8Qx1pLk7vT2nW9cJ4fR6hB0sZ5gE1uN7aK3oXwI"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"         description:"When the confirmation token expires, only set on a dry run."`
+}
+
+type GameStatsOut struct {
+	Questions           QuestionStatsOut   `json:"questions"              description:"The statistics of the questions of the game."`
+	Stories             int                `json:"stories"                description:"The number of stories of the game."                                   example:"12"`
+	LatestStory         *time.Time         `json:"latest_story,omitempty" description:"When the most recent story was added, not set if there are no stories."`
+	TranslationCoverage map[string]float64 `json:"translation_coverage"   description:"The percentage of questions translated into each language."`
+}
+
+type QuestionStatsOut struct {
+	Total      int            `json:"total"       description:"The number of questions."                    example:"40"`
+	Enabled    int            `json:"enabled"     description:"The number of enabled questions."            example:"35"`
+	Disabled   int            `json:"disabled"    description:"The number of disabled questions."           example:"5"`
+	ByRound    map[string]int `json:"by_round"    description:"The number of questions in each round."`
+	ByGroup    map[string]int `json:"by_group"    description:"The number of questions in each group."`
+	ByLanguage map[string]int `json:"by_language" description:"The number of questions translated into each language."`
+	Stories    map[string]int `json:"stories"     description:"The number of stories about each question, by question ID."`
 }
 
 type ListGameParams struct {
-	Games string `query:"games" enum:"enabled,disabled,all" default:"all"`
+	Games    string `query:"games"    enum:"enabled,disabled,all" default:"all"`
+	Language string `query:"language" description:"Only get games available in this language."                          example:"en"`
+	Region   string `query:"region"   description:"Only get games available in this region (ISO 3166-1 alpha-2 code)." example:"GB"`
+}
+
+type UpdateEnableInput struct {
+	internal.GameParams
+	Language string `query:"language" description:"Only enable or disable the game in this language." example:"fr"`
 }
 
 type ListUserParams struct {
@@ -0,0 +1,53 @@
+package games
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/questions"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/story"
+)
+
+// GameDefinition ties together everything the API needs to know about a game, how to validate its questions
+// and how to convert its stories (including the type its answers are stored as). Each game lives in its own
+// package, i.e. `games/quibly`, which provides its definition.
+type GameDefinition struct {
+	Name      string
+	Questions questions.Questioner
+	Story     story.Gamer
+}
+
+var definitions = map[string]GameDefinition{}
+
+// Register adds games to the registry, all game lookups in the questions and story packages go through it.
+func Register(gameDefinitions ...GameDefinition) {
+	for _, definition := range gameDefinitions {
+		register(definition)
+	}
+}
+
+func register(definition GameDefinition) {
+	definitions[definition.Name] = definition
+	questions.RegisterGame(definition.Name, definition.Questions)
+	story.RegisterGame(definition.Name, definition.Story)
+}
+
+func GetDefinition(name string) (GameDefinition, error) {
+	definition, ok := definitions[name]
+	if !ok {
+		return GameDefinition{}, errors.NotFoundf("Game %s", name)
+	}
+
+	return definition, nil
+}
+
+func RegisteredNames() []string {
+	names := []string{}
+	for name := range definitions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
@@ -1,17 +1,27 @@
 package games
 
 import (
+	"time"
+
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/core/database"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/questions"
 )
 
-const QUIBLY = "quibly"
-const FIBBINGIT = "fibbing_it"
-const DRAWLOSSEUM = "drawlosseum"
-
 type Game struct {
-	Name     string `bson:"name"`
-	RulesURL string `bson:"rules_url,omitempty" json:"rules_url,omitempty"`
-	Enabled  *bool  `bson:"enabled,omitempty"`
+	Name            string               `bson:"name"`
+	RulesURL        string               `bson:"rules_url,omitempty"        json:"rules_url,omitempty"`
+	Enabled         *bool                `bson:"enabled,omitempty"`
+	Rules           *questions.GameRules `bson:"rules,omitempty"            json:"rules,omitempty"`
+	MinPlayers      int                  `bson:"min_players,omitempty"      json:"min_players,omitempty"`
+	MaxPlayers      int                  `bson:"max_players,omitempty"      json:"max_players,omitempty"`
+	DurationMinutes int                  `bson:"duration_minutes,omitempty" json:"duration_minutes,omitempty"`
+	DisplayNames    map[string]string    `bson:"display_names,omitempty"    json:"display_names,omitempty"`
+	Descriptions    map[string]string    `bson:"descriptions,omitempty"     json:"descriptions,omitempty"`
+	Tags            []string             `bson:"tags,omitempty"             json:"tags,omitempty"`
+	Icon            string               `bson:"icon,omitempty"             json:"icon,omitempty"`
+	Languages       []string             `bson:"languages,omitempty"        json:"languages,omitempty"`
+	Regions         []string             `bson:"regions,omitempty"          json:"regions,omitempty"`
+	RetentionDays   int                  `bson:"retention_days,omitempty"   json:"retention_days,omitempty"`
 }
 
 func (game *Game) Add(db database.Database) (bool, error) {
@@ -29,6 +39,18 @@ func (game *Game) Update(db database.Database, filter map[string]interface{}) (b
 	return updated, err
 }
 
+type UpdateGame map[string]interface{}
+
+func (game *UpdateGame) Add(db database.Database, filter map[string]interface{}) (bool, error) {
+	updated, err := db.UpdateObject("game", filter, game)
+	return updated, err
+}
+
+func (game *UpdateGame) Remove(db database.Database, filter map[string]interface{}) (bool, error) {
+	removed, err := db.RemoveObject("game", filter, game)
+	return removed, err
+}
+
 type Games []Game
 
 func (games *Games) Add(db database.Database) error {
@@ -57,3 +79,48 @@ func (games Games) ToInterface() []interface{} {
 	}
 	return interfaceObject
 }
+
+// Removal is everything that is removed along with a game.
+type Removal struct {
+	Questions    int `bson:"questions"`
+	Translations int `bson:"translations"`
+	Stories      int `bson:"stories"`
+}
+
+// RemovalToken confirms the removal of a game, it is random so it cannot be guessed. It can only be used until it
+// expires and while the counts from the preview are still up to date.
+type RemovalToken struct {
+	Token     string    `bson:"token"`
+	GameName  string    `bson:"game_name"`
+	Removal   Removal   `bson:",inline"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+func (token *RemovalToken) Add(db database.Database) (bool, error) {
+	inserted, err := db.Insert("removal", token)
+	return inserted, err
+}
+
+func (token *RemovalToken) Get(db database.Database, filter map[string]interface{}) error {
+	err := db.Get("removal", filter, token)
+	return err
+}
+
+func (token *RemovalToken) Update(db database.Database, filter map[string]interface{}) (bool, error) {
+	updated, err := db.Update("removal", filter, token)
+	return updated, err
+}
+
+// Stats are the statistics of the questions and stories of a game.
+type Stats struct {
+	Questions           int
+	EnabledQuestions    int
+	DisabledQuestions   int
+	QuestionsByRound    map[string]int
+	QuestionsByGroup    map[string]int
+	QuestionsByLanguage map[string]int
+	StoriesByQuestion   map[string]int
+	TranslationCoverage map[string]float64
+	Stories             int
+	LatestStory         *time.Time
+}
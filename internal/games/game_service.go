@@ -1,12 +1,23 @@
 package games
 
 import (
+	"crypto/rand"
+	"encoding/base64"
+	"math"
+	"sort"
+	"time"
+
 	"github.com/juju/errors"
+	"golang.org/x/text/language"
 
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/core/database"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/questions"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/story"
 )
 
+// removalTokenLifetime is how long the confirmation token from a removal preview can be used for.
+const removalTokenLifetime = 10 * time.Minute
+
 type GameService struct {
 	DB   database.Database
 	Name string
@@ -25,6 +36,12 @@ func (g *GameService) Add(rulesURL string) error {
 		Enabled:  &t,
 	}
 
+	definition, err := GetDefinition(g.Name)
+	if err == nil {
+		rules := definition.Questions.DefaultRules()
+		game.Rules = &rules
+	}
+
 	inserted, err := game.Add(g.DB)
 	if !inserted {
 		return errors.Errorf("Failed to add the new game %s", g.Name)
@@ -46,13 +63,36 @@ func (g *GameService) Get() (*Game, error) {
 	return game, nil
 }
 
-func (g *GameService) GetAll(enabled *bool) (Games, error) {
+// GetAll gets the games, optionally filtered by their enabled state and the language and region they are
+// available in. Games without any languages (or regions) are available in all of them.
+func (g *GameService) GetAll(enabled *bool, languageCode string, region string) (Games, error) {
 	games := Games{}
 	filter := map[string]interface{}{}
 	if enabled != nil {
 		filter["enabled"] = *enabled
 	}
 
+	availability := []interface{}{}
+	if languageCode != "" {
+		tag, err := language.Parse(languageCode)
+		if err != nil {
+			return Games{}, errors.BadRequestf("invalid language %s", languageCode)
+		}
+		availability = append(availability, availableInFilter("languages", tag.String()))
+	}
+
+	if region != "" {
+		parsedRegion, err := language.ParseRegion(region)
+		if err != nil {
+			return Games{}, errors.BadRequestf("invalid region %s", region)
+		}
+		availability = append(availability, availableInFilter("regions", parsedRegion.String()))
+	}
+
+	if len(availability) > 0 {
+		filter["$and"] = availability
+	}
+
 	err := games.Get(g.DB, filter)
 	if err != nil {
 		return Games{}, err
@@ -60,26 +100,245 @@ func (g *GameService) GetAll(enabled *bool) (Games, error) {
 	return games, nil
 }
 
-func (g *GameService) Remove() error {
-	exists := g.doesItExist()
-	if !exists {
-		return errors.NotFoundf("the game %s", g.Name)
+func availableInFilter(field string, value string) map[string]interface{} {
+	return map[string]interface{}{
+		"$or": []interface{}{
+			map[string]interface{}{field: map[string]interface{}{"$exists": false}},
+			map[string]interface{}{field: map[string]interface{}{"$size": 0}},
+			map[string]interface{}{field: value},
+		},
+	}
+}
+
+// PreviewRemove counts what would be removed along with the game, without removing anything. The confirmation token
+// it returns is needed to remove the game.
+func (g *GameService) PreviewRemove() (RemovalToken, error) {
+	removal, err := g.countRemoval()
+	if err != nil {
+		return RemovalToken{}, err
+	}
+
+	filter := map[string]interface{}{
+		"game_name":  g.Name,
+		"expires_at": map[string]interface{}{"$lte": time.Now()},
+	}
+	_, err = g.DB.DeleteMany("removal", filter)
+	if err != nil {
+		return RemovalToken{}, errors.Errorf("failed to delete expired removal tokens %v", err)
+	}
+
+	token, err := newRemovalToken()
+	if err != nil {
+		return RemovalToken{}, err
+	}
+
+	removalToken := RemovalToken{
+		Token:     token,
+		GameName:  g.Name,
+		Removal:   removal,
+		ExpiresAt: time.Now().Add(removalTokenLifetime).UTC(),
+	}
+
+	inserted, err := removalToken.Add(g.DB)
+	if !inserted || err != nil {
+		return RemovalToken{}, errors.Errorf("failed to add removal token %v", err)
+	}
+
+	return removalToken, nil
+}
+
+// Remove removes the game along with its questions, stories and their share links. The confirmation token must be
+// from a preview that hasn't expired, and if anything has changed since the preview the game is not removed.
+func (g *GameService) Remove(confirmationToken string) (Removal, error) {
+	removal, err := g.countRemoval()
+	if err != nil {
+		return Removal{}, err
+	}
+
+	if confirmationToken == "" {
+		return Removal{}, errors.BadRequestf("missing confirmation token, preview the removal with dry_run first")
+	}
+
+	removalToken := RemovalToken{}
+	err = removalToken.Get(g.DB, map[string]interface{}{"token": confirmationToken, "game_name": g.Name})
+	if err != nil || !removalToken.ExpiresAt.After(time.Now()) {
+		return Removal{}, errors.BadRequestf("invalid confirmation token, preview the removal with dry_run again")
+	} else if removalToken.Removal != removal {
+		return Removal{}, errors.BadRequestf("invalid confirmation token, the game has changed since it was previewed")
 	}
 
-	questions := questions.Questions{}
 	filter := map[string]interface{}{"game_name": g.Name}
-	_, err := questions.Delete(g.DB, filter)
+	_, err = questions.Questions{}.Delete(g.DB, filter)
 	if err != nil {
-		return err
+		return Removal{}, err
+	}
+
+	_, err = story.Stories{}.Delete(g.DB, filter)
+	if err != nil {
+		return Removal{}, err
+	}
+
+	_, err = g.DB.DeleteMany("share", filter)
+	if err != nil {
+		return Removal{}, errors.Errorf("failed to delete share links %v", err)
+	}
+
+	_, err = g.DB.DeleteMany("removal", filter)
+	if err != nil {
+		return Removal{}, errors.Errorf("failed to delete removal tokens %v", err)
 	}
 
 	filter = map[string]interface{}{"name": g.Name}
 	deleted, err := g.DB.Delete("game", filter)
 	if !deleted || err != nil {
-		return errors.Errorf("failed to remove game %s", g.Name)
+		return Removal{}, errors.Errorf("failed to remove game %s", g.Name)
 	}
 
-	return nil
+	return removal, nil
+}
+
+// countRemoval counts what is removed along with the game. The translations are counted by the database, so the
+// questions are never loaded.
+func (g *GameService) countRemoval() (Removal, error) {
+	exists := g.doesItExist()
+	if !exists {
+		return Removal{}, errors.NotFoundf("the game %s", g.Name)
+	}
+
+	filter := map[string]interface{}{"game_name": g.Name}
+	questionCount, err := g.DB.Count("question", filter)
+	if err != nil {
+		return Removal{}, errors.Errorf("failed to count questions for game %s %v", g.Name, err)
+	}
+
+	byLanguage, err := g.DB.CountByKey("question", filter, "content")
+	if err != nil {
+		return Removal{}, errors.Errorf("failed to count translations for game %s %v", g.Name, err)
+	}
+
+	storyCount, err := g.DB.Count("story", filter)
+	if err != nil {
+		return Removal{}, errors.Errorf("failed to count stories for game %s %v", g.Name, err)
+	}
+
+	removal := Removal{Questions: int(questionCount), Stories: int(storyCount)}
+	for _, count := range byLanguage {
+		removal.Translations += count
+	}
+
+	return removal, nil
+}
+
+func newRemovalToken() (string, error) {
+	token := make([]byte, 32)
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", errors.Errorf("failed to create removal token %v", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// GetStats gets the statistics of the game. They are calculated by the database, so the questions and stories are
+// never loaded. Translation coverage is the percentage of the game's questions that have a translation in a language.
+func (g *GameService) GetStats() (Stats, error) {
+	exists := g.doesItExist()
+	if !exists {
+		return Stats{}, errors.NotFoundf("the game %s", g.Name)
+	}
+
+	filter := map[string]interface{}{"game_name": g.Name}
+	groupFilter := map[string]interface{}{"game_name": g.Name, "group.name": map[string]interface{}{"$exists": true}}
+
+	total, err := g.DB.Count("question", filter)
+	if err != nil {
+		return Stats{}, errors.Errorf("failed to count questions for game %s %v", g.Name, err)
+	}
+
+	byEnabled, err := g.DB.CountByField("question", filter, "enabled")
+	if err != nil {
+		return Stats{}, errors.Errorf("failed to count enabled questions for game %s %v", g.Name, err)
+	}
+
+	byRound, err := g.DB.CountByField("question", filter, "round")
+	if err != nil {
+		return Stats{}, errors.Errorf("failed to count questions by round for game %s %v", g.Name, err)
+	}
+	delete(byRound, "")
+
+	byGroup, err := g.DB.CountByField("question", groupFilter, "group.name")
+	if err != nil {
+		return Stats{}, errors.Errorf("failed to count questions by group for game %s %v", g.Name, err)
+	}
+
+	byLanguage, err := g.DB.CountByKey("question", filter, "content")
+	if err != nil {
+		return Stats{}, errors.Errorf("failed to count questions by language for game %s %v", g.Name, err)
+	}
+
+	stories, err := g.DB.Count("story", filter)
+	if err != nil {
+		return Stats{}, errors.Errorf("failed to count stories for game %s %v", g.Name, err)
+	}
+
+	questionFilter := map[string]interface{}{
+		"game_name":            g.Name,
+		"metadata.question_id": map[string]interface{}{"$exists": true},
+	}
+	storiesByQuestion, err := g.DB.CountByField("story", questionFilter, "metadata.question_id")
+	if err != nil {
+		return Stats{}, errors.Errorf("failed to count stories by question for game %s %v", g.Name, err)
+	}
+
+	latestStory, err := g.DB.GetLatestTime("story", filter, "_id")
+	if err != nil {
+		return Stats{}, errors.Errorf("failed to get latest story for game %s %v", g.Name, err)
+	}
+
+	coverage := map[string]float64{}
+	for languageCode, count := range byLanguage {
+		coverage[languageCode] = math.Round(float64(count)*1000/float64(total)) / 10
+	}
+
+	stats := Stats{
+		Questions:           int(total),
+		EnabledQuestions:    byEnabled["true"],
+		DisabledQuestions:   int(total) - byEnabled["true"],
+		QuestionsByRound:    byRound,
+		QuestionsByGroup:    byGroup,
+		QuestionsByLanguage: byLanguage,
+		StoriesByQuestion:   storiesByQuestion,
+		TranslationCoverage: coverage,
+		Stories:             int(stories),
+		LatestStory:         latestStory,
+	}
+	return stats, nil
+}
+
+// PurgeExpiredStories deletes the stories that are older than the retention period of their game and returns how
+// many were deleted for each game. Games without a retention period keep their stories forever.
+func (g *GameService) PurgeExpiredStories(now time.Time) (map[string]int, error) {
+	games, err := g.GetAll(nil, "", "")
+	if err != nil {
+		return nil, errors.Errorf("failed to get games %v", err)
+	}
+
+	storyService := story.StoryService{DB: g.DB}
+	purged := map[string]int{}
+	for _, game := range games {
+		if game.RetentionDays <= 0 {
+			continue
+		}
+
+		before := now.AddDate(0, 0, -game.RetentionDays)
+		deleted, err := storyService.Purge(game.Name, before)
+		if err != nil {
+			return purged, err
+		}
+		purged[game.Name] = deleted
+	}
+
+	return purged, nil
 }
 
 func (g *GameService) UpdateEnable(enabled bool) (bool, error) {
@@ -98,6 +357,218 @@ func (g *GameService) UpdateEnable(enabled bool) (bool, error) {
 	return updated, err
 }
 
+// UpdateLanguageEnable enables or disables the game in a single language, it doesn't change whether the game is
+// enabled. If a game without any languages (so available in all of them) is disabled in a language, it is made
+// available in every other language its questions are in.
+func (g *GameService) UpdateLanguageEnable(languageCode string, enabled bool) (bool, error) {
+	game, err := g.Get()
+	if game.Name == "" || err != nil {
+		return false, errors.NotFoundf("The game %s", g.Name)
+	}
+
+	tag, err := language.Parse(languageCode)
+	if err != nil {
+		return false, errors.BadRequestf("invalid language %s", languageCode)
+	}
+	languageCode = tag.String()
+
+	languages := game.Languages
+	if enabled {
+		if len(languages) == 0 || contains(languages, languageCode) {
+			return true, nil
+		}
+		languages = append(languages, languageCode)
+	} else {
+		if len(languages) == 0 {
+			filter := map[string]interface{}{"game_name": g.Name}
+			languages, err = g.DB.GetUniqueKeys("question", filter, "content")
+			if err != nil {
+				return false, errors.Errorf("failed to get languages of game %s %v", g.Name, err)
+			}
+		}
+
+		languages = remove(languages, languageCode)
+		if len(languages) == 0 {
+			return false, errors.BadRequestf("cannot disable every language of game %s, disable the game instead", g.Name)
+		}
+	}
+
+	sort.Strings(languages)
+	update := UpdateGame{"languages": languages}
+	filter := map[string]interface{}{"name": g.Name}
+	_, err = update.Add(g.DB, filter)
+	if err != nil {
+		return false, errors.Errorf("Failed to update languages of game %s %v", g.Name, err)
+	}
+
+	return true, nil
+}
+
+func contains(items []string, item string) bool {
+	for _, existing := range items {
+		if existing == item {
+			return true
+		}
+	}
+
+	return false
+}
+
+func remove(items []string, item string) []string {
+	remaining := []string{}
+	for _, existing := range items {
+		if existing != item {
+			remaining = append(remaining, existing)
+		}
+	}
+
+	return remaining
+}
+
+func (g *GameService) UpdateMetadata(metadata GameMetadataIn) (*Game, error) {
+	game, err := g.Get()
+	if game.Name == "" || err != nil {
+		return &Game{}, errors.NotFoundf("The game %s", g.Name)
+	}
+
+	update, err := newMetadataUpdate(game, metadata)
+	if err != nil {
+		return &Game{}, err
+	}
+
+	if len(update) > 0 {
+		filter := map[string]interface{}{"name": g.Name}
+		_, err = update.Add(g.DB, filter)
+		if err != nil {
+			return &Game{}, errors.Errorf("Failed to update metadata of game %s %v", g.Name, err)
+		}
+	}
+
+	return g.Get()
+}
+
+// newMetadataUpdate returns only the fields that have been set, validated against the current state of the game
+// i.e. so only the maximum number of players can be updated.
+func newMetadataUpdate(game *Game, metadata GameMetadataIn) (UpdateGame, error) {
+	update := UpdateGame{}
+	minPlayers, maxPlayers := game.MinPlayers, game.MaxPlayers
+
+	if metadata.MinPlayers != nil {
+		minPlayers = *metadata.MinPlayers
+		update["min_players"] = minPlayers
+	}
+	if metadata.MaxPlayers != nil {
+		maxPlayers = *metadata.MaxPlayers
+		update["max_players"] = maxPlayers
+	}
+
+	if minPlayers < 0 || maxPlayers < 0 {
+		return nil, errors.BadRequestf("number of players cannot be negative")
+	} else if minPlayers > 0 && maxPlayers > 0 && minPlayers > maxPlayers {
+		return nil, errors.BadRequestf("min players %d is larger than max players %d", minPlayers, maxPlayers)
+	}
+
+	if metadata.DurationMinutes != nil {
+		if *metadata.DurationMinutes < 0 {
+			return nil, errors.BadRequestf("duration cannot be negative")
+		}
+		update["duration_minutes"] = *metadata.DurationMinutes
+	}
+
+	localised := map[string]*map[string]string{
+		"display_names": metadata.DisplayNames,
+		"descriptions":  metadata.Descriptions,
+	}
+	for field, values := range localised {
+		if values == nil {
+			continue
+		}
+
+		for languageCode := range *values {
+			_, err := language.Parse(languageCode)
+			if err != nil {
+				return nil, errors.BadRequestf("invalid language code %s in %s", languageCode, field)
+			}
+		}
+		update[field] = *values
+	}
+
+	if metadata.Tags != nil {
+		for _, tag := range *metadata.Tags {
+			if tag == "" {
+				return nil, errors.BadRequestf("tags cannot be empty")
+			}
+		}
+		update["tags"] = *metadata.Tags
+	}
+
+	if metadata.Icon != nil {
+		update["icon"] = *metadata.Icon
+	}
+
+	if metadata.RetentionDays != nil {
+		if *metadata.RetentionDays < 0 {
+			return nil, errors.BadRequestf("retention days cannot be negative")
+		}
+		update["retention_days"] = *metadata.RetentionDays
+	}
+
+	if metadata.Languages != nil {
+		languages := []string{}
+		for _, languageCode := range *metadata.Languages {
+			tag, err := language.Parse(languageCode)
+			if err != nil {
+				return nil, errors.BadRequestf("invalid language code %s in languages", languageCode)
+			}
+			languages = append(languages, tag.String())
+		}
+		update["languages"] = languages
+	}
+
+	if metadata.Regions != nil {
+		regions := []string{}
+		for _, region := range *metadata.Regions {
+			parsedRegion, err := language.ParseRegion(region)
+			if err != nil {
+				return nil, errors.BadRequestf("invalid region %s in regions", region)
+			}
+			regions = append(regions, parsedRegion.String())
+		}
+		update["regions"] = regions
+	}
+
+	return update, nil
+}
+
+func (g *GameService) GetRules() (questions.GameRules, error) {
+	exists := g.doesItExist()
+	if !exists {
+		return questions.GameRules{}, errors.NotFoundf("The game %s", g.Name)
+	}
+
+	return questions.GetRules(g.DB, g.Name)
+}
+
+func (g *GameService) UpdateRules(rules questions.GameRules) error {
+	exists := g.doesItExist()
+	if !exists {
+		return errors.NotFoundf("The game %s", g.Name)
+	}
+
+	err := rules.Validate()
+	if err != nil {
+		return err
+	}
+
+	updateGame := &Game{Name: g.Name, Rules: &rules}
+	filter := map[string]interface{}{"name": g.Name}
+	_, err = updateGame.Update(g.DB, filter)
+	if err != nil {
+		return errors.Errorf("Failed to update rules of game %s %v", g.Name, err)
+	}
+	return nil
+}
+
 func (g *GameService) doesItExist() bool {
 	game, err := g.Get()
 	if err != nil {
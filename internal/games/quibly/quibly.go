@@ -0,0 +1,166 @@
+package quibly
+
+import (
+	"github.com/juju/errors"
+
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/games"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/questions"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/story"
+)
+
+const Name = "quibly"
+
+func Game() games.GameDefinition {
+	return games.GameDefinition{
+		Name:      Name,
+		Questions: Questions{},
+		Story:     Story{},
+	}
+}
+
+type Questions struct{}
+
+func (q Questions) DefaultRules() questions.GameRules {
+	return questions.GameRules{
+		Rounds: []questions.RoundRules{
+			{Name: "pair"},
+			{Name: "group"},
+			{Name: "answers"},
+		},
+	}
+}
+
+func (q Questions) ValidateQuestion(question questions.QuestionIn, rules questions.GameRules) error {
+	return rules.ValidateQuestion(question)
+}
+
+type Story struct{}
+
+func (q Story) NewAnswers() story.StoryAnswerType {
+	return &story.QuiblyAnswers{}
+}
+
+func (q Story) NewStoryOut(s story.Story) (story.StoryInOut, error) {
+	storyAnswers, ok := s.Answers.(*story.QuiblyAnswers)
+	if !ok {
+		return story.StoryInOut{}, errors.Errorf("invalid answer for Quibly")
+	}
+
+	answers := q.newAnswersOut(storyAnswers)
+	newStory := story.StoryInOut{
+		Question: s.Question,
+		Round:    s.Round,
+		Results:  newResults(*storyAnswers),
+		StoryAnswersInOut: story.StoryAnswersInOut{
+			Quibly: answers,
+		},
+	}
+	return newStory, nil
+}
+
+func (q Story) NewTranscript(s story.Story, _ story.ImageOptions) (story.Transcript, error) {
+	storyAnswers, ok := s.Answers.(*story.QuiblyAnswers)
+	if !ok {
+		return story.Transcript{}, errors.Errorf("invalid answer for Quibly")
+	}
+
+	transcript := story.Transcript{Question: s.Question, Round: s.Round}
+	for _, answer := range scoreAnswers(*storyAnswers) {
+		votes := answer.Votes
+		transcript.Entries = append(transcript.Entries, story.TranscriptEntry{
+			Nickname: answer.Nickname,
+			Answer:   answer.Answer,
+			Votes:    &votes,
+		})
+	}
+
+	return transcript, nil
+}
+
+func (q Story) newAnswersOut(storyAnswers *story.QuiblyAnswers) story.QuiblyAnswersInOut {
+	var answers story.QuiblyAnswersInOut
+	for _, storyAnswer := range *storyAnswers {
+		answer := story.QuiblyAnswerInOut(storyAnswer)
+		answers = append(answers, answer)
+	}
+
+	return answers
+}
+
+// NewStory allows a story without answers if it is in progress, as the answers can be appended later.
+func (q Story) NewStory(s story.StoryInOut) (story.Story, error) {
+	answers := story.QuiblyAnswers{}
+	if !s.InProgress || len(s.StoryAnswersInOut.Quibly) > 0 {
+		var err error
+		answers, err = q.newAnswers(s.StoryAnswersInOut.Quibly)
+		if err != nil {
+			return story.Story{}, err
+		}
+	}
+
+	newStory := story.Story{
+		Question: s.Question,
+		Round:    s.Round,
+		Answers:  answers,
+	}
+	return newStory, nil
+}
+
+func (q Story) newAnswers(storyAnswers story.QuiblyAnswersInOut) (story.QuiblyAnswers, error) {
+	var answers story.QuiblyAnswers
+	if len(storyAnswers) == 0 {
+		return story.QuiblyAnswers{}, errors.BadRequestf("no answers in the story.")
+	}
+
+	for _, storyAnswer := range storyAnswers {
+		answer := story.QuiblyAnswer(storyAnswer)
+		if answer.Votes < 0 {
+			return story.QuiblyAnswers{}, errors.BadRequestf("player %s cannot have negative votes.", answer.Nickname)
+		}
+		answers = append(answers, answer)
+	}
+
+	return answers, nil
+}
+
+// AppendAnswers adds the answers of new players to the story, answers of players already in the story add their votes
+// to the player's existing answer. So votes can be added as they come in, without repeating the answer.
+func (q Story) AppendAnswers(s story.Story, storyAnswers story.StoryAnswersInOut) (story.Story, error) {
+	existingAnswers, ok := s.Answers.(*story.QuiblyAnswers)
+	if !ok {
+		return story.Story{}, errors.Errorf("invalid answer for Quibly")
+	}
+
+	newAnswers, err := q.newAnswers(storyAnswers.Quibly)
+	if err != nil {
+		return story.Story{}, err
+	}
+
+	answers := append(story.QuiblyAnswers{}, *existingAnswers...)
+	for _, newAnswer := range newAnswers {
+		i := findAnswer(answers, newAnswer.Nickname)
+		switch {
+		case i == -1 && newAnswer.Answer == "":
+			return story.Story{}, errors.BadRequestf("player %s has not answered yet.", newAnswer.Nickname)
+		case i == -1:
+			answers = append(answers, newAnswer)
+		case newAnswer.Answer != "" && newAnswer.Answer != answers[i].Answer:
+			return story.Story{}, errors.BadRequestf("player %s has already answered.", newAnswer.Nickname)
+		default:
+			answers[i].Votes += newAnswer.Votes
+		}
+	}
+
+	s.Answers = &answers
+	return s, nil
+}
+
+func findAnswer(answers story.QuiblyAnswers, nickname string) int {
+	for i, answer := range answers {
+		if answer.Nickname == nickname {
+			return i
+		}
+	}
+
+	return -1
+}
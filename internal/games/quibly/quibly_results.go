@@ -0,0 +1,79 @@
+package quibly
+
+import (
+	"math"
+	"sort"
+
+	"github.com/juju/errors"
+
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/story"
+)
+
+// ScoreAnswers ranks the answers of the story by their votes, the ones with the most votes first. Answers with the
+// same number of votes share a rank, and the next rank is skipped, i.e. 1, 1, 3.
+func (q Story) ScoreAnswers(s story.Story) ([]story.ScoredAnswer, error) {
+	answers, ok := s.Answers.(*story.QuiblyAnswers)
+	if !ok {
+		return nil, errors.Errorf("invalid answer for Quibly")
+	}
+
+	return scoreAnswers(*answers), nil
+}
+
+func scoreAnswers(answers story.QuiblyAnswers) []story.ScoredAnswer {
+	totalVotes := 0
+	for _, answer := range answers {
+		totalVotes += answer.Votes
+	}
+
+	scored := []story.ScoredAnswer{}
+	for _, answer := range answers {
+		share := 0.0
+		if totalVotes > 0 {
+			share = math.Round(float64(answer.Votes)/float64(totalVotes)*10000) / 10000
+		}
+
+		scored = append(scored, story.ScoredAnswer{
+			Nickname: answer.Nickname,
+			Answer:   answer.Answer,
+			Votes:    answer.Votes,
+			Share:    share,
+		})
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].Votes > scored[j].Votes
+	})
+
+	for i := range scored {
+		scored[i].Rank = i + 1
+		if i > 0 && scored[i].Votes == scored[i-1].Votes {
+			scored[i].Rank = scored[i-1].Rank
+		}
+	}
+
+	return scored
+}
+
+// newResults works out the winners of the story, the players whose answers have the most votes. There is no winner if
+// nobody voted.
+func newResults(answers story.QuiblyAnswers) *story.StoryResultsOut {
+	results := story.StoryResultsOut{
+		Winners: []string{},
+		Ranks:   []story.AnswerRankOut{},
+	}
+
+	for _, answer := range scoreAnswers(answers) {
+		results.TotalVotes += answer.Votes
+		results.Ranks = append(results.Ranks, story.AnswerRankOut(answer))
+	}
+
+	for _, answer := range results.Ranks {
+		if results.TotalVotes > 0 && answer.Rank == 1 {
+			results.Winners = append(results.Winners, answer.Nickname)
+		}
+	}
+
+	results.Tie = len(results.Winners) > 1
+	return &results
+}
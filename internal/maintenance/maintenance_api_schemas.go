@@ -3,3 +3,17 @@ package maintenance
 type Healthcheck struct {
 	Message string `json:"message" description:"The status of the API." example:"The API is healthy"`
 }
+
+type Liveness struct {
+	Status string `json:"status" description:"Whether the API is running." example:"ok"`
+}
+
+type Readiness struct {
+	Ready  bool                      `json:"ready"  description:"Whether the API passed every check and can serve requests."`
+	Checks map[string]ReadinessCheck `json:"checks" description:"The result of each check, i.e. database, indexes and shutdown."`
+}
+
+type ReadinessCheck struct {
+	Ready   bool   `json:"ready"             description:"Whether the check passed."`
+	Message string `json:"message,omitempty" description:"Why the check failed." example:"failed to ping the database"`
+}
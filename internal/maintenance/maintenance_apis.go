@@ -1,6 +1,8 @@
 package maintenance
 
 import (
+	"net/http"
+
 	log "github.com/sirupsen/logrus"
 
 	"github.com/gin-gonic/gin"
@@ -11,9 +13,10 @@ import (
 )
 
 type MaintenanceAPI struct {
-	Conf   core.Conf
-	Logger *log.Logger
-	DB     database.Database
+	Conf     core.Conf
+	Logger   *log.Logger
+	DB       database.Database
+	Shutdown *Shutdown
 }
 
 func (env *MaintenanceAPI) Healthcheck(_ *gin.Context) (*Healthcheck, error) {
@@ -27,3 +30,34 @@ func (env *MaintenanceAPI) Healthcheck(_ *gin.Context) (*Healthcheck, error) {
 		Message: "The API is healthy.",
 	}, nil
 }
+
+// Livez only checks the API is running, it doesn't depend on the database so the API isn't restarted when the
+// database is down.
+func (env *MaintenanceAPI) Livez(_ *gin.Context) (*Liveness, error) {
+	return &Liveness{Status: "ok"}, nil
+}
+
+// Readyz checks whether the API can serve requests, the response has the result of each check and its status is 503
+// if any check failed.
+func (env *MaintenanceAPI) Readyz(c *gin.Context) (*Readiness, error) {
+	m := MaintenanceService{DB: env.DB, Shutdown: env.Shutdown}
+	readiness := &Readiness{Ready: true, Checks: map[string]ReadinessCheck{}}
+	for name, err := range m.CheckReadiness() {
+		check := ReadinessCheck{Ready: err == nil}
+		if err != nil {
+			check.Message = err.Error()
+			readiness.Ready = false
+		}
+		readiness.Checks[name] = check
+	}
+
+	if !readiness.Ready {
+		env.Logger.WithFields(log.Fields{
+			"checks": readiness.Checks,
+		}).Warn("The API is not ready.")
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, readiness)
+		return nil, nil
+	}
+
+	return readiness, nil
+}
@@ -0,0 +1,80 @@
+package maintenance
+
+import (
+	"sync/atomic"
+
+	"github.com/juju/errors"
+
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/core/database"
+)
+
+// Shutdown records whether the API is shutting down, so it stops being ready while requests are drained.
+type Shutdown struct {
+	inProgress int32
+}
+
+func (s *Shutdown) Start() {
+	atomic.StoreInt32(&s.inProgress, 1)
+}
+
+// InProgress is false for a nil Shutdown, i.e. when the API isn't told about shutdowns.
+func (s *Shutdown) InProgress() bool {
+	return s != nil && atomic.LoadInt32(&s.inProgress) == 1
+}
+
+type MaintenanceService struct {
+	DB       database.Database
+	Shutdown *Shutdown
+}
+
+// CheckReadiness runs each of the checks the API must pass to serve requests, a check passed if its error is nil.
+func (m *MaintenanceService) CheckReadiness() map[string]error {
+	checks := map[string]error{
+		"database": nil,
+		"indexes":  nil,
+		"shutdown": nil,
+	}
+
+	if !m.DB.Ping() {
+		checks["database"] = errors.Errorf("failed to ping the database")
+		checks["indexes"] = errors.Errorf("cannot get indexes without the database")
+	} else {
+		checks["indexes"] = m.checkIndexes()
+	}
+
+	if m.Shutdown.InProgress() {
+		checks["shutdown"] = errors.Errorf("the API is shutting down")
+	}
+
+	return checks
+}
+
+func (m *MaintenanceService) checkIndexes() error {
+	missing := []string{}
+	for _, index := range database.Indexes {
+		fields, err := m.DB.GetIndexes(index.Collection)
+		if err != nil {
+			return errors.Errorf("failed to get indexes of %s %v", index.Collection, err)
+		}
+
+		if !contains(fields, index.Field) {
+			missing = append(missing, index.Collection+"."+index.Field)
+		}
+	}
+
+	if len(missing) > 0 {
+		return errors.Errorf("missing indexes %v", missing)
+	}
+
+	return nil
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+
+	return false
+}
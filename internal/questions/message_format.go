@@ -0,0 +1,515 @@
+package questions
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/juju/errors"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// messagePart is a single piece of a parsed ICU MessageFormat string. It is either plain text, a `#` (the number
+// of the enclosing plural argument) or an argument i.e. `{player}` or `{count, plural, one {...} other {...}}`.
+type messagePart struct {
+	text     string
+	pound    bool
+	argument string
+	argType  string
+	offset   int
+	options  map[string][]messagePart
+}
+
+type messageParser struct {
+	input []rune
+	pos   int
+}
+
+var pluralForms = map[plural.Form]string{
+	plural.Other: "other",
+	plural.Zero:  "zero",
+	plural.One:   "one",
+	plural.Two:   "two",
+	plural.Few:   "few",
+	plural.Many:  "many",
+}
+
+// parseMessage parses a subset of the ICU MessageFormat syntax. It supports simple arguments (`{name}`), typed
+// arguments (`{count, number}`) and the complex `plural`, `selectordinal` and `select` arguments, which may be nested.
+// A `plural` argument may start with an `offset:`, which is subtracted from the number before picking the plural form
+// and replacing `#`, exact matches such as `=1` still use the number itself.
+func parseMessage(content string) ([]messagePart, error) {
+	parser := messageParser{input: []rune(content)}
+	parts, err := parser.parseParts(false, false)
+	if err != nil {
+		return nil, errors.BadRequestf("invalid message format in %q, %s", content, err)
+	}
+
+	return parts, nil
+}
+
+// getPlaceholders returns the sorted, unique names of all the arguments used in the content.
+func getPlaceholders(content string) ([]string, error) {
+	parts, err := parseMessage(content)
+	if err != nil {
+		return nil, err
+	}
+
+	unique := map[string]struct{}{}
+	collectPlaceholders(parts, unique)
+
+	placeholders := []string{}
+	for placeholder := range unique {
+		placeholders = append(placeholders, placeholder)
+	}
+
+	sort.Strings(placeholders)
+	return placeholders, nil
+}
+
+func collectPlaceholders(parts []messagePart, unique map[string]struct{}) {
+	for _, part := range parts {
+		if part.argument == "" {
+			continue
+		}
+
+		unique[part.argument] = struct{}{}
+		for _, option := range part.options {
+			collectPlaceholders(option, unique)
+		}
+	}
+}
+
+// validatePlaceholders checks the content uses the same set of placeholders as every other translation of a question.
+// The translation being replaced, if any, is ignored.
+func validatePlaceholders(content string, languageCode string, translations map[string]string) error {
+	placeholders, err := getPlaceholders(content)
+	if err != nil {
+		return err
+	}
+
+	expected := strings.Join(placeholders, ",")
+	for translationLanguage, translation := range translations {
+		if translationLanguage == languageCode {
+			continue
+		}
+
+		existing, err := getPlaceholders(translation)
+		if err != nil {
+			return err
+		}
+
+		if strings.Join(existing, ",") != expected {
+			return errors.BadRequestf(
+				"placeholders %v do not match placeholders %v used by language %s",
+				placeholders,
+				existing,
+				translationLanguage,
+			)
+		}
+	}
+
+	return nil
+}
+
+// ValidateTranslations checks every translation of a question is valid and uses the same set of placeholders.
+func ValidateTranslations(translations map[string]string) error {
+	for languageCode, content := range translations {
+		err := validatePlaceholders(content, languageCode, translations)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renderMessage substitutes the values into the content. Plural categories are chosen using the CLDR plural rules for
+// the language code.
+func renderMessage(content string, languageCode string, values map[string]string) (string, error) {
+	parts, err := parseMessage(content)
+	if err != nil {
+		return "", err
+	}
+
+	tag, err := language.Parse(languageCode)
+	if err != nil {
+		return "", errors.BadRequestf("invalid language %s", languageCode)
+	}
+
+	var builder strings.Builder
+	err = renderParts(&builder, parts, tag, values, "")
+	if err != nil {
+		return "", err
+	}
+
+	return builder.String(), nil
+}
+
+func renderParts(
+	builder *strings.Builder,
+	parts []messagePart,
+	tag language.Tag,
+	values map[string]string,
+	pound string,
+) error {
+	for _, part := range parts {
+		switch {
+		case part.pound:
+			builder.WriteString(pound)
+		case part.argument == "":
+			builder.WriteString(part.text)
+		default:
+			err := renderArgument(builder, part, tag, values)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func renderArgument(builder *strings.Builder, part messagePart, tag language.Tag, values map[string]string) error {
+	value, ok := values[part.argument]
+	if !ok {
+		return errors.BadRequestf("missing value for placeholder %s", part.argument)
+	}
+
+	switch part.argType {
+	case "plural", "selectordinal":
+		rules := plural.Cardinal
+		if part.argType == "selectordinal" {
+			rules = plural.Ordinal
+		}
+
+		number, err := subtractOffset(value, part.offset)
+		if err != nil {
+			return errors.BadRequestf("value %s for placeholder %s must be a number", value, part.argument)
+		}
+
+		form, err := matchPluralForm(rules, tag, number)
+		if err != nil {
+			return errors.BadRequestf("value %s for placeholder %s must be a number", value, part.argument)
+		}
+
+		option, ok := part.options["="+value]
+		if !ok {
+			option, ok = part.options[form]
+		}
+		if !ok {
+			option = part.options["other"]
+		}
+		return renderParts(builder, option, tag, values, number)
+	case "select":
+		option, ok := part.options[value]
+		if !ok {
+			option = part.options["other"]
+		}
+		return renderParts(builder, option, tag, values, "")
+	default:
+		builder.WriteString(value)
+		return nil
+	}
+}
+
+// subtractOffset subtracts the plural offset from the value, keeping the number of fraction digits in the value as
+// they change which plural form is used.
+func subtractOffset(value string, offset int) (string, error) {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return "", err
+	} else if offset == 0 {
+		return value, nil
+	}
+
+	precision := 0
+	if i := strings.Index(value, "."); i >= 0 {
+		precision = len(value) - i - 1
+	}
+
+	return strconv.FormatFloat(number-float64(offset), 'f', precision, 64), nil
+}
+
+func matchPluralForm(rules *plural.Rules, tag language.Tag, value string) (string, error) {
+	number := strings.TrimPrefix(value, "-")
+	if _, err := strconv.ParseFloat(number, 64); err != nil {
+		return "", err
+	}
+
+	integer, fraction := number, ""
+	if i := strings.Index(number, "."); i >= 0 {
+		integer, fraction = number[:i], number[i+1:]
+	}
+
+	digits := []byte{}
+	for _, digit := range integer + fraction {
+		if digit < '0' || digit > '9' {
+			return "", fmt.Errorf("invalid digit %c", digit)
+		}
+		digits = append(digits, byte(digit-'0'))
+	}
+
+	form := rules.MatchDigits(tag, digits, len(integer), len(fraction))
+	return pluralForms[form], nil
+}
+
+func (p *messageParser) parseParts(nested bool, inPlural bool) ([]messagePart, error) {
+	parts := []messagePart{}
+	var text strings.Builder
+
+	flushText := func() {
+		if text.Len() > 0 {
+			parts = append(parts, messagePart{text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for p.pos < len(p.input) {
+		char := p.input[p.pos]
+		switch {
+		case char == '{':
+			flushText()
+			part, err := p.parseArgument()
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, part)
+		case char == '}':
+			if !nested {
+				return nil, fmt.Errorf("unexpected '}' at position %d", p.pos)
+			}
+			flushText()
+			return parts, nil
+		case char == '#' && inPlural:
+			flushText()
+			parts = append(parts, messagePart{pound: true})
+			p.pos++
+		case char == '\'':
+			p.parseQuoted(&text, inPlural)
+		default:
+			text.WriteRune(char)
+			p.pos++
+		}
+	}
+
+	if nested {
+		return nil, fmt.Errorf("missing closing '}'")
+	}
+
+	flushText()
+	return parts, nil
+}
+
+// parseQuoted handles ICU apostrophe quoting, two apostrophes are a literal apostrophe and an apostrophe before a
+// syntax character starts quoted literal text, which runs until the next single apostrophe.
+func (p *messageParser) parseQuoted(text *strings.Builder, inPlural bool) {
+	p.pos++
+	if p.pos < len(p.input) && p.input[p.pos] == '\'' {
+		text.WriteRune('\'')
+		p.pos++
+		return
+	}
+
+	if p.pos >= len(p.input) || !isSyntaxChar(p.input[p.pos], inPlural) {
+		text.WriteRune('\'')
+		return
+	}
+
+	for p.pos < len(p.input) {
+		char := p.input[p.pos]
+		p.pos++
+		if char != '\'' {
+			text.WriteRune(char)
+			continue
+		}
+
+		if p.pos < len(p.input) && p.input[p.pos] == '\'' {
+			text.WriteRune('\'')
+			p.pos++
+			continue
+		}
+		return
+	}
+}
+
+func isSyntaxChar(char rune, inPlural bool) bool {
+	return char == '{' || char == '}' || (inPlural && char == '#')
+}
+
+func (p *messageParser) parseArgument() (messagePart, error) {
+	p.pos++
+	p.skipWhitespace()
+
+	name := p.parseWord()
+	if name == "" {
+		return messagePart{}, fmt.Errorf("missing placeholder name at position %d", p.pos)
+	}
+
+	part := messagePart{argument: name}
+	p.skipWhitespace()
+	if p.consume('}') {
+		return part, nil
+	}
+
+	if !p.consume(',') {
+		return messagePart{}, fmt.Errorf("expected ',' or '}' after placeholder %s", name)
+	}
+
+	p.skipWhitespace()
+	part.argType = p.parseWord()
+	p.skipWhitespace()
+
+	switch part.argType {
+	case "plural", "selectordinal", "select":
+		if !p.consume(',') {
+			return messagePart{}, fmt.Errorf("expected ',' after %s in placeholder %s", part.argType, name)
+		}
+
+		if part.argType == "plural" {
+			offset, err := p.parseOffset(name)
+			if err != nil {
+				return messagePart{}, err
+			}
+			part.offset = offset
+		}
+
+		options, err := p.parseOptions(part.argType != "select")
+		if err != nil {
+			return messagePart{}, err
+		}
+		part.options = options
+		return part, nil
+	case "number", "date", "time", "spellout", "ordinal", "duration":
+		if p.consume('}') {
+			return part, nil
+		}
+
+		if !p.consume(',') {
+			return messagePart{}, fmt.Errorf("expected ',' or '}' after %s in placeholder %s", part.argType, name)
+		}
+		return part, p.skipStyle(name)
+	default:
+		return messagePart{}, fmt.Errorf("unknown type %q for placeholder %s", part.argType, name)
+	}
+}
+
+func (p *messageParser) parseOptions(isPlural bool) (map[string][]messagePart, error) {
+	options := map[string][]messagePart{}
+
+	for {
+		p.skipWhitespace()
+		if p.consume('}') {
+			break
+		}
+
+		selector := p.parseSelector()
+		if selector == "" {
+			return nil, fmt.Errorf("missing selector at position %d", p.pos)
+		}
+
+		if strings.HasPrefix(selector, "offset:") {
+			return nil, fmt.Errorf("unexpected %s, an offset must be the first option of a plural", selector)
+		}
+
+		p.skipWhitespace()
+		if !p.consume('{') {
+			return nil, fmt.Errorf("expected '{' after selector %s", selector)
+		}
+
+		message, err := p.parseParts(true, isPlural)
+		if err != nil {
+			return nil, err
+		}
+		p.pos++
+		options[selector] = message
+	}
+
+	if _, ok := options["other"]; !ok {
+		return nil, fmt.Errorf("missing 'other' option")
+	}
+
+	return options, nil
+}
+
+// parseOffset parses the optional `offset:` at the start of the options of a plural argument i.e. `offset:1`.
+func (p *messageParser) parseOffset(name string) (int, error) {
+	p.skipWhitespace()
+	start := p.pos
+	selector := p.parseSelector()
+	if !strings.HasPrefix(selector, "offset:") {
+		p.pos = start
+		return 0, nil
+	}
+
+	value := strings.TrimPrefix(selector, "offset:")
+	if value == "" {
+		p.skipWhitespace()
+		value = p.parseSelector()
+	}
+
+	offset, err := strconv.Atoi(value)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid offset %q in placeholder %s, must be a whole number of at least 0", value, name)
+	}
+
+	return offset, nil
+}
+
+func (p *messageParser) skipStyle(name string) error {
+	for p.pos < len(p.input) {
+		char := p.input[p.pos]
+		p.pos++
+		if char == '}' {
+			return nil
+		} else if char == '{' {
+			return fmt.Errorf("unexpected '{' in style of placeholder %s", name)
+		}
+	}
+
+	return fmt.Errorf("missing closing '}' for placeholder %s", name)
+}
+
+func (p *messageParser) parseWord() string {
+	start := p.pos
+	for p.pos < len(p.input) {
+		char := p.input[p.pos]
+		if !unicode.IsLetter(char) && !unicode.IsDigit(char) && char != '_' {
+			break
+		}
+		p.pos++
+	}
+
+	return string(p.input[start:p.pos])
+}
+
+func (p *messageParser) parseSelector() string {
+	start := p.pos
+	for p.pos < len(p.input) {
+		char := p.input[p.pos]
+		if unicode.IsSpace(char) || char == '{' || char == '}' {
+			break
+		}
+		p.pos++
+	}
+
+	return string(p.input[start:p.pos])
+}
+
+func (p *messageParser) skipWhitespace() {
+	for p.pos < len(p.input) && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *messageParser) consume(char rune) bool {
+	if p.pos < len(p.input) && p.input[p.pos] == char {
+		p.pos++
+		return true
+	}
+
+	return false
+}
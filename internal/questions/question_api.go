@@ -67,7 +67,17 @@ func (env *QuestionAPI) validateQuestion(gameName string, question QuestionIn) e
 		return err
 	}
 
-	err = game.ValidateQuestion(question)
+	rules, err := GetRules(env.DB, gameName)
+	if err != nil {
+		return err
+	}
+
+	err = game.ValidateQuestion(question, rules)
+	if err != nil {
+		return err
+	}
+
+	_, err = getPlaceholders(question.Content)
 	if err != nil {
 		return err
 	}
@@ -199,6 +209,55 @@ func newGenericQuestionOut(question Question, languageCode string) (QuestionGene
 	return questionOut, nil
 }
 
+func (env *QuestionAPI) GetFullQuestion(_ *gin.Context, questionInput *GetFullQuestionInput) (QuestionFullOut, error) {
+	var (
+		questionID = questionInput.ID
+		gameName   = questionInput.GameName
+	)
+	questionLogger := env.Logger.WithFields(log.Fields{
+		"question_id": questionID,
+		"game_name":   gameName,
+	})
+	questionLogger.Debug("Trying to get question with all translations.")
+
+	q := QuestionService{
+		DB:         env.DB,
+		GameName:   gameName,
+		QuestionID: questionID,
+	}
+	question, err := q.Get()
+	if err != nil {
+		questionLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Failed to get question.")
+		return QuestionFullOut{}, err
+	}
+
+	return newQuestionFullOut(question), nil
+}
+
+func newQuestionFullOut(question Question) QuestionFullOut {
+	questionOut := QuestionFullOut{
+		ID:      question.ID,
+		Content: question.Content,
+		Round:   question.Round,
+		Enabled: *question.Enabled,
+	}
+
+	if question.Content == nil {
+		questionOut.Content = map[string]string{}
+	}
+
+	if question.Group != nil {
+		questionOut.Group = &QuestionGroupInOut{
+			Name: question.Group.Name,
+			Type: question.Group.Type,
+		}
+	}
+
+	return questionOut
+}
+
 func (env *QuestionAPI) GetQuestionsIDs(_ *gin.Context, questionInput *GetQuestionIDsInput) (AllQuestionOut, error) {
 	var (
 		gameName = questionInput.GameName
@@ -383,6 +442,45 @@ func (env *QuestionAPI) AddTranslation(_ *gin.Context, questionInput *AddTransla
 	return nil
 }
 
+func (env *QuestionAPI) RenderQuestion(_ *gin.Context, questionInput *RenderQuestionInput) (QuestionRenderOut, error) {
+	var (
+		questionID = questionInput.ID
+		gameName   = questionInput.GameName
+		lang       = questionInput.Language
+	)
+	questionLogger := env.Logger.WithFields(log.Fields{
+		"question_id":   questionID,
+		"game_name":     gameName,
+		"language_code": lang,
+		"values":        questionInput.Values,
+	})
+	questionLogger.Debug("Trying to render question.")
+
+	_, err := language.Parse(lang)
+	if err != nil {
+		questionLogger.WithFields(log.Fields{
+			"err":           err,
+			"language_code": lang,
+		}).Warn("Bad language code.")
+		return QuestionRenderOut{}, errors.BadRequestf("invalid language %s", lang)
+	}
+
+	q := QuestionService{
+		DB:         env.DB,
+		GameName:   gameName,
+		QuestionID: questionID,
+	}
+	content, err := q.Render(lang, questionInput.Values)
+	if err != nil {
+		questionLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Failed to render question.")
+		return QuestionRenderOut{}, err
+	}
+
+	return QuestionRenderOut{Content: content}, nil
+}
+
 func (env *QuestionAPI) RemoveTranslation(_ *gin.Context, questionInput *QuestionInput) error {
 	var (
 		questionID = questionInput.QuestionIDParams.ID
@@ -23,6 +23,14 @@ type QuestionGenericOut struct {
 	Group   *QuestionGroupInOut `json:"group,omitempty"`
 }
 
+type QuestionFullOut struct {
+	ID      string              `json:"id"              description:"The id for a specific question."                                       example:"a-random-id"`
+	Content map[string]string   `json:"content"         description:"The question in every language it has been translated to, keyed by language code."`
+	Round   string              `json:"round,omitempty" description:"If the game has rounds, specify the round in this field."               example:"opinion"`
+	Enabled bool                `json:"enabled"         description:"True if the question is enabled and can be used in a game, else false."`
+	Group   *QuestionGroupInOut `json:"group,omitempty"`
+}
+
 type AllQuestionOut struct {
 	IDs    []string `json:"ids"    description:"All the question ids."`
 	Cursor string   `json:"cursor" description:"The next question id (for pagination)."`
@@ -37,6 +45,14 @@ type QuestionTranslationIn struct {
 	Content string `json:"content" description:"The question in the new language" example:"Willst du eine Frage?" validate:"required"`
 }
 
+type QuestionRenderIn struct {
+	Values map[string]string `json:"values" description:"The values to substitute into the question placeholders, keyed by placeholder name."`
+}
+
+type QuestionRenderOut struct {
+	Content string `json:"content" description:"The question with all placeholders substituted." example:"What would Liam do with a million pounds?"`
+}
+
 type AddQuestionInput struct {
 	internal.GameParams
 	QuestionIn
@@ -80,6 +96,11 @@ type GetQuestionInput struct {
 	QuestionIDParams
 }
 
+type GetFullQuestionInput struct {
+	internal.GameParams
+	QuestionIDParams
+}
+
 type AddTranslationInput struct {
 	internal.GameParams
 	LanguageParams
@@ -87,6 +108,13 @@ type AddTranslationInput struct {
 	QuestionTranslationIn
 }
 
+type RenderQuestionInput struct {
+	internal.GameParams
+	LanguageParams
+	QuestionIDParams
+	QuestionRenderIn
+}
+
 type GroupInput struct {
 	internal.GameParams
 	internal.RoundParams
@@ -0,0 +1,195 @@
+package questions
+
+import (
+	"unicode/utf8"
+
+	"github.com/juju/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/core/database"
+)
+
+// GameRules are the question rules for a game, they are stored in the game document so they can be changed without
+// a code release. If a game document has no rules the defaults from the game's Questioner are used.
+type GameRules struct {
+	Rounds []RoundRules `bson:"rounds" json:"rounds" yaml:"rounds"`
+}
+
+type RoundRules struct {
+	Name             string   `bson:"name"                         json:"name"                         yaml:"name"`
+	HasGroups        bool     `bson:"has_groups"                   json:"has_groups"                   yaml:"has_groups"`
+	GroupTypes       []string `bson:"group_types,omitempty"        json:"group_types,omitempty"        yaml:"group_types"`
+	MinContentLength int      `bson:"min_content_length,omitempty" json:"min_content_length,omitempty" yaml:"min_content_length"`
+	MaxContentLength int      `bson:"max_content_length,omitempty" json:"max_content_length,omitempty" yaml:"max_content_length"`
+}
+
+func (rules GameRules) GetRound(name string) (RoundRules, bool) {
+	for _, round := range rules.Rounds {
+		if round.Name == name {
+			return round, true
+		}
+	}
+
+	return RoundRules{}, false
+}
+
+func (rules GameRules) HasGroups(round string) bool {
+	roundRules, ok := rules.GetRound(round)
+	return ok && roundRules.HasGroups
+}
+
+// ValidateQuestion checks the question is for a valid round, its group is valid for that round and its content is
+// within the round's limits. Groups are ignored for rounds that don't have groups.
+func (rules GameRules) ValidateQuestion(question QuestionIn) error {
+	round, ok := rules.GetRound(question.Round)
+	if !ok {
+		return errors.BadRequestf("invalid round %s", question.Round)
+	}
+
+	group := question.Group
+	if group != nil && round.HasGroups {
+		if group.Name == "" {
+			return errors.BadRequestf("missing group information")
+		} else if group.Type != "" && !round.isValidGroupType(group.Type) {
+			return errors.BadRequestf("invalid group type %s", group.Type)
+		}
+	}
+
+	return round.ValidateContent(question.Content)
+}
+
+func (round RoundRules) ValidateContent(content string) error {
+	length := utf8.RuneCountInString(content)
+	if round.MinContentLength > 0 && length < round.MinContentLength {
+		return errors.BadRequestf("content must be at least %d characters", round.MinContentLength)
+	} else if round.MaxContentLength > 0 && length > round.MaxContentLength {
+		return errors.BadRequestf("content must be at most %d characters", round.MaxContentLength)
+	}
+
+	return nil
+}
+
+// Examples returns valid example questions for the round, one without a group and, if the round has groups, one for
+// each type of group.
+func (round RoundRules) Examples() []QuestionIn {
+	content := exampleContent(round.MinContentLength, round.MaxContentLength)
+	examples := []QuestionIn{
+		{Content: content, LanguageCode: "en", Round: round.Name},
+	}
+
+	if !round.HasGroups {
+		return examples
+	}
+
+	groupTypes := round.GroupTypes
+	if len(groupTypes) == 0 {
+		groupTypes = []string{""}
+	}
+
+	for _, groupType := range groupTypes {
+		examples = append(examples, QuestionIn{
+			Content:      content,
+			LanguageCode: "en",
+			Round:        round.Name,
+			Group: &QuestionGroupInOut{
+				Name: "example_group",
+				Type: groupType,
+			},
+		})
+	}
+
+	return examples
+}
+
+func exampleContent(minLength int, maxLength int) string {
+	content := []rune("This is a funny question?")
+	for len(content) < minLength {
+		content = append(content, '?')
+	}
+
+	if maxLength > 0 && len(content) > maxLength {
+		content = content[:maxLength]
+	}
+
+	return string(content)
+}
+
+func (round RoundRules) isValidGroupType(groupType string) bool {
+	for _, validType := range round.GroupTypes {
+		if validType == groupType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate checks the rules themselves are valid, i.e. before they are stored in the game document.
+func (rules GameRules) Validate() error {
+	validTypes := map[string]bool{"answer": true, "question": true}
+	seen := map[string]bool{}
+
+	for _, round := range rules.Rounds {
+		if round.Name == "" {
+			return errors.BadRequestf("missing round name")
+		} else if seen[round.Name] {
+			return errors.BadRequestf("duplicate round %s", round.Name)
+		}
+		seen[round.Name] = true
+
+		for _, groupType := range round.GroupTypes {
+			if !validTypes[groupType] {
+				return errors.BadRequestf("invalid group type %s for round %s", groupType, round.Name)
+			}
+		}
+
+		if round.MinContentLength < 0 || round.MaxContentLength < 0 {
+			return errors.BadRequestf("content limits for round %s cannot be negative", round.Name)
+		} else if round.MaxContentLength > 0 && round.MinContentLength > round.MaxContentLength {
+			return errors.BadRequestf("minimum content length is larger than the maximum for round %s", round.Name)
+		}
+	}
+
+	return nil
+}
+
+// GetRules gets the rules stored in the game document, falling back to the game's default rules.
+func GetRules(db database.Database, gameName string) (GameRules, error) {
+	game, err := GetGame(gameName)
+	if err != nil {
+		return GameRules{}, err
+	}
+
+	filter := map[string]interface{}{"name": gameName}
+	document := &gameRulesDocument{}
+	err = document.Get(db, filter)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return GameRules{}, errors.Errorf("failed to get rules for game %s %v", gameName, err)
+	}
+
+	if document.Rules == nil {
+		return game.DefaultRules(), nil
+	}
+
+	return *document.Rules, nil
+}
+
+// gameRulesDocument is the part of the game document the questions package needs.
+type gameRulesDocument struct {
+	Name  string     `bson:"name"`
+	Rules *GameRules `bson:"rules,omitempty"`
+}
+
+func (game *gameRulesDocument) Add(db database.Database) (bool, error) {
+	return false, errors.NotSupportedf("adding a game from its rules")
+}
+
+func (game *gameRulesDocument) Get(db database.Database, filter map[string]interface{}) error {
+	err := db.Get("game", filter, game)
+	return err
+}
+
+func (game *gameRulesDocument) Update(db database.Database, filter map[string]interface{}) (bool, error) {
+	updated, err := db.Update("game", filter, game)
+	return updated, err
+}
@@ -146,11 +146,28 @@ func (q *QuestionService) Remove() error {
 }
 
 func (q *QuestionService) AddTranslation(content string, langCode string) error {
-	err := q.validateFound()
+	question, err := q.get()
+	if err != nil {
+		return errors.NotFoundf("the question with ID %s for game %s", q.QuestionID, q.GameName)
+	}
+
+	err = validatePlaceholders(content, langCode, question.Content)
+	if err != nil {
+		return err
+	}
+
+	rules, err := GetRules(q.DB, q.GameName)
 	if err != nil {
 		return err
 	}
 
+	if round, ok := rules.GetRound(question.Round); ok {
+		err = round.ValidateContent(content)
+		if err != nil {
+			return err
+		}
+	}
+
 	filter := q.filter()
 	path := fmt.Sprintf("content.%s", langCode)
 	translation := UpdateQuestion{
@@ -165,6 +182,24 @@ func (q *QuestionService) AddTranslation(content string, langCode string) error
 	return nil
 }
 
+func (q *QuestionService) Render(languageCode string, values map[string]string) (string, error) {
+	question, err := q.Get()
+	if err != nil {
+		return "", err
+	}
+
+	content, ok := question.Content[languageCode]
+	if !ok {
+		return "", errors.NotFoundf("question with id %s and language code %s", q.QuestionID, languageCode)
+	}
+
+	if values == nil {
+		values = map[string]string{}
+	}
+
+	return renderMessage(content, languageCode, values)
+}
+
 func (q *QuestionService) RemoveTranslation(languageCode string) error {
 	question, err := q.get()
 	_, ok := question.Content[languageCode]
@@ -209,12 +244,12 @@ func (q *QuestionService) UpdateEnable(enabled bool) (bool, error) {
 }
 
 func (q *QuestionService) GetGroups(round string) ([]string, error) {
-	game, err := GetGame(q.GameName)
+	rules, err := GetRules(q.DB, q.GameName)
 	if err != nil {
 		return []string{}, err
 	}
 
-	if !game.HasGroups(round) {
+	if !rules.HasGroups(round) {
 		return nil, errors.NotFoundf("cannot get question groups from round %s of game %s", round, q.GameName)
 	}
 
@@ -293,19 +328,23 @@ func (q *QuestionService) filter() map[string]interface{} {
 }
 
 type Questioner interface {
-	ValidateQuestion(question QuestionIn) error
-	HasGroups(_ string) bool
+	DefaultRules() GameRules
+	ValidateQuestion(question QuestionIn, rules GameRules) error
+}
+
+var questioners = map[string]Questioner{}
+
+// RegisterGame makes the question rules of a game available through GetGame. Games should be registered through
+// games.Register rather than calling this directly.
+func RegisterGame(name string, questioner Questioner) {
+	questioners[name] = questioner
 }
 
 func GetGame(name string) (Questioner, error) {
-	switch name {
-	case "quibly":
-		return Quibly{}, nil
-	case "fibbing_it":
-		return FibbingIt{}, nil
-	case "drawlosseum":
-		return Drawlosseum{}, nil
-	default:
+	questioner, ok := questioners[name]
+	if !ok {
 		return nil, errors.NotFoundf("Game %s", name)
 	}
+
+	return questioner, nil
 }
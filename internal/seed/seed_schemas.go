@@ -0,0 +1,58 @@
+package seed
+
+import (
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/questions"
+)
+
+// Seed is the declarative description of the games (and their starter questions) that should exist in the database.
+type Seed struct {
+	Games []GameSeed `yaml:"games" json:"games"`
+}
+
+type GameSeed struct {
+	Name            string               `yaml:"name"             json:"name"`
+	RulesURL        string               `yaml:"rules_url"        json:"rules_url"`
+	Enabled         *bool                `yaml:"enabled"          json:"enabled"`
+	Rules           *questions.GameRules `yaml:"rules"            json:"rules"`
+	MinPlayers      int                  `yaml:"min_players"      json:"min_players"`
+	MaxPlayers      int                  `yaml:"max_players"      json:"max_players"`
+	DurationMinutes int                  `yaml:"duration_minutes" json:"duration_minutes"`
+	DisplayNames    map[string]string    `yaml:"display_names"    json:"display_names"`
+	Descriptions    map[string]string    `yaml:"descriptions"     json:"descriptions"`
+	Tags            []string             `yaml:"tags"             json:"tags"`
+	Icon            string               `yaml:"icon"             json:"icon"`
+	Languages       []string             `yaml:"languages"        json:"languages"`
+	Regions         []string             `yaml:"regions"          json:"regions"`
+	RetentionDays   int                  `yaml:"retention_days"   json:"retention_days"`
+	Questions       []QuestionSeed       `yaml:"questions"        json:"questions"`
+}
+
+// DefaultLanguage is the language questions without an ID are matched by, so they must have content in it.
+const DefaultLanguage = "en"
+
+// QuestionSeed is a question to seed. If the ID is not set, an existing question is matched using its content in the
+// default language.
+type QuestionSeed struct {
+	ID      string             `yaml:"id"      json:"id"`
+	Round   string             `yaml:"round"   json:"round"`
+	Enabled *bool              `yaml:"enabled" json:"enabled"`
+	Content map[string]string  `yaml:"content" json:"content"`
+	Group   *QuestionGroupSeed `yaml:"group"   json:"group"`
+}
+
+type QuestionGroupSeed struct {
+	Name string `yaml:"name" json:"name"`
+	Type string `yaml:"type" json:"type"`
+}
+
+// Report counts what was done to each type of item, when a seed was applied.
+type Report struct {
+	Games     Counts
+	Questions Counts
+}
+
+type Counts struct {
+	Created int
+	Updated int
+	Skipped int
+}
@@ -0,0 +1,361 @@
+package seed
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/juju/errors"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/text/language"
+
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/core/database"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/games"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/questions"
+)
+
+// SeedService applies a seed to the database. It is idempotent, items that are missing are created and existing
+// items are either left alone or, if Update is set, updated to match the seed.
+type SeedService struct {
+	DB     database.Database
+	Logger *log.Logger
+	Update bool
+}
+
+func Load(path string) (Seed, error) {
+	var seed Seed
+	err := cleanenv.ReadConfig(path, &seed)
+	if err != nil {
+		return Seed{}, fmt.Errorf("error reading seed file %w", err)
+	}
+
+	return seed, nil
+}
+
+func (s *SeedService) Apply(seed Seed) (Report, error) {
+	report := Report{}
+
+	for _, game := range seed.Games {
+		err := s.validateGame(game)
+		if err != nil {
+			return report, err
+		}
+	}
+
+	for _, game := range seed.Games {
+		err := s.applyGame(game, &report)
+		if err != nil {
+			return report, err
+		}
+
+		for _, question := range game.Questions {
+			err = s.applyQuestion(game.Name, question, &report)
+			if err != nil {
+				return report, err
+			}
+		}
+	}
+
+	s.Logger.WithFields(log.Fields{
+		"games_created":     report.Games.Created,
+		"games_updated":     report.Games.Updated,
+		"games_skipped":     report.Games.Skipped,
+		"questions_created": report.Questions.Created,
+		"questions_updated": report.Questions.Updated,
+		"questions_skipped": report.Questions.Skipped,
+	}).Info("Applied seed.")
+	return report, nil
+}
+
+func (s *SeedService) validateGame(game GameSeed) error {
+	if game.Name == "" {
+		return errors.NotValidf("seed game without a name")
+	}
+
+	definition, err := games.GetDefinition(game.Name)
+	if err != nil {
+		return errors.NotValidf("seed game %s, it is not a registered game", game.Name)
+	}
+
+	rules := definition.Questions.DefaultRules()
+	if game.Rules != nil {
+		rules = *game.Rules
+		err = rules.Validate()
+		if err != nil {
+			return errors.NotValidf("rules of seed game %s, %v", game.Name, err)
+		}
+	}
+
+	if game.RetentionDays < 0 {
+		return errors.NotValidf("retention days of seed game %s", game.Name)
+	}
+
+	for _, languageCode := range game.Languages {
+		_, err = language.Parse(languageCode)
+		if err != nil {
+			return errors.NotValidf("language %s of seed game %s", languageCode, game.Name)
+		}
+	}
+
+	for _, region := range game.Regions {
+		_, err = language.ParseRegion(region)
+		if err != nil {
+			return errors.NotValidf("region %s of seed game %s", region, game.Name)
+		}
+	}
+
+	for _, question := range game.Questions {
+		err = validateQuestion(definition.Questions, rules, question)
+		if err != nil {
+			return errors.NotValidf("seed question %v for game %s, %v", question.Content, game.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func validateQuestion(questioner questions.Questioner, rules questions.GameRules, question QuestionSeed) error {
+	if len(question.Content) == 0 {
+		return errors.New("missing content")
+	} else if _, ok := question.Content[DefaultLanguage]; !ok && question.ID == "" {
+		return errors.Errorf("questions without an id need content in %s, to match them with existing questions", DefaultLanguage)
+	}
+
+	for languageCode, content := range question.Content {
+		_, err := language.Parse(languageCode)
+		if err != nil {
+			return errors.Errorf("invalid language code %s", languageCode)
+		}
+
+		questionIn := questions.QuestionIn{
+			Content:      content,
+			LanguageCode: languageCode,
+			Round:        question.Round,
+		}
+		if question.Group != nil {
+			questionIn.Group = &questions.QuestionGroupInOut{
+				Name: question.Group.Name,
+				Type: question.Group.Type,
+			}
+		}
+
+		err = questioner.ValidateQuestion(questionIn, rules)
+		if err != nil {
+			return err
+		}
+	}
+
+	return questions.ValidateTranslations(question.Content)
+}
+
+func (s *SeedService) applyGame(seed GameSeed, report *Report) error {
+	gameLogger := s.Logger.WithFields(log.Fields{
+		"game_name": seed.Name,
+	})
+
+	filter := map[string]interface{}{"name": seed.Name}
+	existing := &games.Game{}
+	err := existing.Get(s.DB, filter)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return errors.Errorf("failed to get game %s %v", seed.Name, err)
+	}
+
+	game := newGame(seed)
+	update := newGameUpdate(seed)
+	switch {
+	case existing.Name == "":
+		gameLogger.Info("Seeding new game.")
+		inserted, err := game.Add(s.DB)
+		if !inserted || err != nil {
+			return errors.Errorf("failed to add game %s %v", seed.Name, err)
+		}
+		report.Games.Created++
+	case s.Update && len(update) > 0:
+		gameLogger.Info("Updating existing game from seed.")
+		_, err = update.Add(s.DB, filter)
+		if err != nil {
+			return errors.Errorf("failed to update game %s %v", seed.Name, err)
+		}
+		report.Games.Updated++
+	default:
+		gameLogger.Debug("Game already exists, skipping.")
+		report.Games.Skipped++
+	}
+
+	return nil
+}
+
+func newGame(seed GameSeed) *games.Game {
+	enabled := true
+	if seed.Enabled != nil {
+		enabled = *seed.Enabled
+	}
+
+	game := &games.Game{
+		Name:            seed.Name,
+		RulesURL:        seed.RulesURL,
+		Enabled:         &enabled,
+		Rules:           seed.Rules,
+		MinPlayers:      seed.MinPlayers,
+		MaxPlayers:      seed.MaxPlayers,
+		DurationMinutes: seed.DurationMinutes,
+		DisplayNames:    seed.DisplayNames,
+		Descriptions:    seed.Descriptions,
+		Tags:            seed.Tags,
+		Icon:            seed.Icon,
+		Languages:       seed.Languages,
+		Regions:         seed.Regions,
+		RetentionDays:   seed.RetentionDays,
+	}
+
+	if game.Rules == nil {
+		definition, err := games.GetDefinition(seed.Name)
+		if err == nil {
+			rules := definition.Questions.DefaultRules()
+			game.Rules = &rules
+		}
+	}
+
+	return game
+}
+
+// newGameUpdate only has the fields the seed sets, so fields of the game that were changed through the API and aren't
+// in the seed are kept. Translations are updated one language at a time.
+func newGameUpdate(seed GameSeed) games.UpdateGame {
+	update := games.UpdateGame{}
+	if seed.RulesURL != "" {
+		update["rules_url"] = seed.RulesURL
+	}
+	if seed.Enabled != nil {
+		update["enabled"] = *seed.Enabled
+	}
+	if seed.Rules != nil {
+		update["rules"] = seed.Rules
+	}
+	if seed.MinPlayers != 0 {
+		update["min_players"] = seed.MinPlayers
+	}
+	if seed.MaxPlayers != 0 {
+		update["max_players"] = seed.MaxPlayers
+	}
+	if seed.DurationMinutes != 0 {
+		update["duration_minutes"] = seed.DurationMinutes
+	}
+	for languageCode, displayName := range seed.DisplayNames {
+		update[fmt.Sprintf("display_names.%s", languageCode)] = displayName
+	}
+	for languageCode, description := range seed.Descriptions {
+		update[fmt.Sprintf("descriptions.%s", languageCode)] = description
+	}
+	if len(seed.Tags) > 0 {
+		update["tags"] = seed.Tags
+	}
+	if seed.Icon != "" {
+		update["icon"] = seed.Icon
+	}
+	if len(seed.Languages) > 0 {
+		update["languages"] = seed.Languages
+	}
+	if len(seed.Regions) > 0 {
+		update["regions"] = seed.Regions
+	}
+	if seed.RetentionDays != 0 {
+		update["retention_days"] = seed.RetentionDays
+	}
+
+	return update
+}
+
+func (s *SeedService) applyQuestion(gameName string, seed QuestionSeed, report *Report) error {
+	filter := questionFilter(gameName, seed)
+	existing := &questions.Question{}
+	err := existing.Get(s.DB, filter)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return errors.Errorf("failed to get question %v %v", seed.Content, err)
+	}
+
+	question := newQuestion(gameName, seed)
+	switch {
+	case existing.ID == "":
+		if question.ID == "" {
+			uuidWithHyphen := uuid.New()
+			question.ID = strings.ReplaceAll(uuidWithHyphen.String(), "-", "")
+		}
+
+		inserted, err := question.Add(s.DB)
+		if !inserted || err != nil {
+			return errors.Errorf("failed to add question %v %v", seed.Content, err)
+		}
+		report.Questions.Created++
+	case s.Update:
+		update := newQuestionUpdate(seed)
+		_, err = update.Add(s.DB, map[string]interface{}{"id": existing.ID})
+		if err != nil {
+			return errors.Errorf("failed to update question %v %v", seed.Content, err)
+		}
+		report.Questions.Updated++
+	default:
+		report.Questions.Skipped++
+	}
+
+	return nil
+}
+
+// questionFilter matches a question by its ID if it has one, else by its content in the default language. So the same
+// question is matched each time the seed is applied, even after translations are added.
+func questionFilter(gameName string, seed QuestionSeed) map[string]interface{} {
+	filter := map[string]interface{}{"game_name": gameName}
+	if seed.ID != "" {
+		filter["id"] = seed.ID
+		return filter
+	}
+
+	filter[fmt.Sprintf("content.%s", DefaultLanguage)] = seed.Content[DefaultLanguage]
+	return filter
+}
+
+// newQuestionUpdate only has the fields the seed sets, the content is updated one language at a time so translations
+// that were added through the API are kept.
+func newQuestionUpdate(seed QuestionSeed) questions.UpdateQuestion {
+	update := questions.UpdateQuestion{}
+	for languageCode, content := range seed.Content {
+		update[fmt.Sprintf("content.%s", languageCode)] = content
+	}
+	if seed.Round != "" {
+		update["round"] = seed.Round
+	}
+	if seed.Enabled != nil {
+		update["enabled"] = *seed.Enabled
+	}
+	if seed.Group != nil {
+		update["group"] = questions.QuestionGroup{Name: seed.Group.Name, Type: seed.Group.Type}
+	}
+
+	return update
+}
+
+func newQuestion(gameName string, seed QuestionSeed) *questions.Question {
+	enabled := true
+	if seed.Enabled != nil {
+		enabled = *seed.Enabled
+	}
+
+	question := &questions.Question{
+		ID:       seed.ID,
+		GameName: gameName,
+		Round:    seed.Round,
+		Enabled:  &enabled,
+		Content:  seed.Content,
+	}
+
+	if seed.Group != nil {
+		question.Group = &questions.QuestionGroup{
+			Name: seed.Group.Name,
+			Type: seed.Group.Type,
+		}
+	}
+
+	return question
+}
@@ -1,9 +1,14 @@
 package story
 
 import (
+	"fmt"
+	"net/http"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/juju/errors"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/text/language"
 
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/core"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/core/database"
@@ -35,10 +40,16 @@ func (env *StoryAPI) AddStory(_ *gin.Context, input *NewStoryInput) (string, err
 	}
 
 	id, err := s.Add(serviceStory)
-	if err != nil {
+	if errors.IsBadRequest(err) {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn(("Invalid story."))
+		return "", err
+	} else if err != nil {
 		storyLogger.WithFields(log.Fields{
 			"err": err,
 		}).Error(("Failed to add story."))
+		return "", err
 	}
 
 	return id, nil
@@ -55,9 +66,36 @@ func (env *StoryAPI) newStory(gameName string, story StoryInOut) (Story, error)
 		return Story{}, err
 	}
 
+	newStory.GameName = gameName
+	newStory.InProgress = story.InProgress
+	newStory.Metadata, err = newMetadata(story.Metadata)
+	if err != nil {
+		return Story{}, err
+	}
+
 	return newStory, nil
 }
 
+func newMetadata(metadata *StoryMetadataInOut) (*StoryMetadata, error) {
+	if metadata == nil {
+		return nil, nil
+	}
+
+	if metadata.Language != "" {
+		_, err := language.Parse(metadata.Language)
+		if err != nil {
+			return nil, errors.BadRequestf("invalid language %s", metadata.Language)
+		}
+	}
+
+	if metadata.PlayerCount < 0 {
+		return nil, errors.BadRequestf("player count cannot be negative")
+	}
+
+	newMetadata := StoryMetadata(*metadata)
+	return &newMetadata, nil
+}
+
 func (env *StoryAPI) GetStory(_ *gin.Context, params *CurrentStoryInput) (StoryInOut, error) {
 	storyLogger := env.Logger.WithFields(log.Fields{
 		"story_id":  params.StoryID,
@@ -82,6 +120,83 @@ func (env *StoryAPI) GetStory(_ *gin.Context, params *CurrentStoryInput) (StoryI
 	return srvStories, err
 }
 
+func (env *StoryAPI) ListStories(_ *gin.Context, input *ListStoriesInput) (StoriesOut, error) {
+	storyLogger := env.Logger.WithFields(log.Fields{
+		"game_name": input.GameName,
+		"round":     input.Round,
+		"nickname":  input.Nickname,
+		"question":  input.Question,
+		"from":      input.From,
+		"to":        input.To,
+		"sort":      input.Sort,
+		"limit":     input.Limit,
+		"cursor":    input.Cursor,
+	})
+	storyLogger.Debug("Trying to list stories.")
+
+	params, err := newSearchParams(input)
+	if err != nil {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Invalid time range.")
+		return StoriesOut{}, err
+	}
+
+	s := StoryService{DB: env.DB}
+	stories, cursor, err := s.List(input.GameName, params)
+	if errors.IsNotFound(err) || errors.IsBadRequest(err) {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Failed to list stories.")
+		return StoriesOut{}, err
+	} else if err != nil {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("Failed to list stories.")
+		return StoriesOut{}, err
+	}
+
+	storiesOut := StoriesOut{Stories: []StoryOut{}, Cursor: cursor}
+	for _, story := range stories {
+		storyOut, err := env.newAPIStory(story)
+		if err != nil {
+			storyLogger.Errorf("Failed to convert Story %v", err)
+			return StoriesOut{}, err
+		}
+		storiesOut.Stories = append(storiesOut.Stories, StoryOut{ID: story.ID, StoryInOut: storyOut})
+	}
+
+	return storiesOut, nil
+}
+
+func newSearchParams(input *ListStoriesInput) (SearchParams, error) {
+	params := SearchParams{
+		Round:    input.Round,
+		Nickname: input.Nickname,
+		Question: input.Question,
+		SortBy:   input.Sort,
+		Limit:    input.Limit,
+		Cursor:   input.Cursor,
+	}
+
+	var err error
+	if input.From != "" {
+		params.From, err = time.Parse(time.RFC3339, input.From)
+		if err != nil {
+			return SearchParams{}, errors.BadRequestf("invalid from time %s", input.From)
+		}
+	}
+
+	if input.To != "" {
+		params.To, err = time.Parse(time.RFC3339, input.To)
+		if err != nil {
+			return SearchParams{}, errors.BadRequestf("invalid to time %s", input.To)
+		}
+	}
+
+	return params, nil
+}
+
 func (env *StoryAPI) newAPIStory(story Story) (StoryInOut, error) {
 	game, err := GetGame(story.GameName)
 	if err != nil {
@@ -93,9 +208,360 @@ func (env *StoryAPI) newAPIStory(story Story) (StoryInOut, error) {
 		return StoryInOut{}, err
 	}
 
+	if !story.CreatedAt.IsZero() {
+		createdAt := story.CreatedAt
+		newStory.CreatedAt = &createdAt
+	}
+
+	if story.Metadata != nil {
+		metadata := StoryMetadataInOut(*story.Metadata)
+		newStory.Metadata = &metadata
+	}
+
+	newStory.InProgress = story.InProgress
+	newStory.Likes = story.Likes
+	newStory.Featured = story.Featured
 	return newStory, nil
 }
 
+// GetStoryImage writes the story as an image, it is only supported by games whose stories are drawings.
+func (env *StoryAPI) GetStoryImage(c *gin.Context, input *StoryImageInput) error {
+	storyLogger := env.Logger.WithFields(log.Fields{
+		"story_id":  input.StoryID,
+		"game_name": input.GameName,
+		"format":    input.Format,
+	})
+	storyLogger.Debug("Trying to render story.")
+
+	game, err := GetGame(input.GameName)
+	if err != nil {
+		return errors.NotFoundf("the game %s", input.GameName)
+	}
+
+	renderer, ok := game.(ImageRenderer)
+	if !ok {
+		return errors.BadRequestf("stories of game %s cannot be rendered as an image", input.GameName)
+	}
+
+	s := StoryService{DB: env.DB}
+	story, err := s.Get(input.StoryID, input.GameName)
+	if err != nil {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn(("Story does not exist."))
+		return errors.NotFoundf("the story %s", input.StoryID)
+	}
+
+	options := env.imageOptions()
+
+	render, contentType := renderer.RenderSVG, "image/svg+xml"
+	if input.Format == "png" {
+		render, contentType = renderer.RenderPNG, "image/png"
+	}
+
+	image, err := render(story, options)
+	if err != nil {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("Failed to render story.")
+		return err
+	}
+
+	c.Data(http.StatusOK, contentType, image)
+	return nil
+}
+
+func (env *StoryAPI) AppendAnswers(_ *gin.Context, input *AppendAnswersInput) (StoryInOut, error) {
+	storyLogger := env.Logger.WithFields(log.Fields{
+		"story_id":  input.StoryID,
+		"game_name": input.GameName,
+		"answers":   input.StoryAnswersInOut,
+	})
+	storyLogger.Debug("Trying to append answers to story.")
+
+	s := StoryService{DB: env.DB}
+	story, err := s.Append(input.StoryID, input.GameName, input.StoryAnswersInOut)
+	if errors.IsNotFound(err) || errors.IsBadRequest(err) {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Failed to append answers to story.")
+		return StoryInOut{}, err
+	} else if err != nil {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("Failed to append answers to story.")
+		return StoryInOut{}, err
+	}
+
+	return env.newAPIStory(story)
+}
+
+func (env *StoryAPI) FinaliseStory(_ *gin.Context, params *CurrentStoryInput) (StoryInOut, error) {
+	storyLogger := env.Logger.WithFields(log.Fields{
+		"story_id":  params.StoryID,
+		"game_name": params.GameName,
+	})
+	storyLogger.Debug("Trying to finalise story.")
+
+	s := StoryService{DB: env.DB}
+	story, err := s.Finalise(params.StoryID, params.GameName)
+	if errors.IsNotFound(err) || errors.IsBadRequest(err) {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Failed to finalise story.")
+		return StoryInOut{}, err
+	} else if err != nil {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("Failed to finalise story.")
+		return StoryInOut{}, err
+	}
+
+	return env.newAPIStory(story)
+}
+
+func (env *StoryAPI) ShareStory(_ *gin.Context, input *ShareStoryInput) (ShareOut, error) {
+	storyLogger := env.Logger.WithFields(log.Fields{
+		"story_id":       input.StoryID,
+		"game_name":      input.GameName,
+		"expires_at":     input.ExpiresAt,
+		"mask_nicknames": input.MaskNicknames,
+	})
+	storyLogger.Debug("Trying to share story.")
+
+	s := StoryService{DB: env.DB}
+	share, err := s.Share(input.StoryID, input.GameName, input.ExpiresAt, input.MaskNicknames)
+	if errors.IsNotFound(err) || errors.IsBadRequest(err) {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Failed to share story.")
+		return ShareOut{}, err
+	} else if err != nil {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("Failed to share story.")
+		return ShareOut{}, err
+	}
+
+	return ShareOut{Token: share.Token, ExpiresAt: share.ExpiresAt}, nil
+}
+
+// GetSharedStory gets the story of a share link. The token isn't logged, as anyone with it can view the story.
+func (env *StoryAPI) GetSharedStory(_ *gin.Context, params *ShareTokenParams) (StoryInOut, error) {
+	env.Logger.Debug("Trying to get shared story.")
+
+	s := StoryService{DB: env.DB}
+	story, share, err := s.ResolveShare(params.Token)
+	if err != nil {
+		env.Logger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Failed to get shared story.")
+		return StoryInOut{}, err
+	}
+
+	storyOut, err := env.newAPIStory(story)
+	if err != nil {
+		env.Logger.Errorf("Failed to convert Story %v", err)
+		return StoryInOut{}, err
+	}
+
+	if share.MaskNicknames {
+		storyOut = maskNicknames(storyOut)
+	}
+	return storyOut, nil
+}
+
+func (env *StoryAPI) RevokeShare(_ *gin.Context, input *RevokeShareInput) error {
+	storyLogger := env.Logger.WithFields(log.Fields{
+		"story_id":  input.StoryID,
+		"game_name": input.GameName,
+	})
+	storyLogger.Debug("Trying to revoke share link.")
+
+	s := StoryService{DB: env.DB}
+	err := s.RevokeShare(input.StoryID, input.GameName, input.Token)
+	if errors.IsNotFound(err) {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Share link does not exist.")
+		return err
+	} else if err != nil {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("Failed to revoke share link.")
+		return err
+	}
+
+	return nil
+}
+
+// ErasePlayer handles data removal requests, the nickname isn't logged so no trace of the player is kept.
+func (env *StoryAPI) ErasePlayer(_ *gin.Context, input *ErasePlayerIn) (ErasureReportOut, error) {
+	storyLogger := env.Logger.WithFields(log.Fields{
+		"game_name": input.GameName,
+		"from":      input.From,
+		"to":        input.To,
+		"action":    input.Action,
+	})
+	storyLogger.Debug("Trying to erase player.")
+
+	params := ErasureParams{Nickname: input.Nickname, GameName: input.GameName}
+	report := ErasureReportOut{Action: input.Action, Stories: []ErasedStoryOut{}}
+	switch input.Action {
+	case "", "redact":
+		if env.Conf.Privacy.PseudonymKey == "" {
+			err := errors.NotProvisionedf("the pseudonym key BANTER_BUS_PRIVACY_PSEUDONYM_KEY, needed to redact players, is")
+			storyLogger.WithFields(log.Fields{
+				"err": err,
+			}).Error("Failed to erase player.")
+			return ErasureReportOut{}, err
+		}
+
+		report.Action = "redact"
+		params.Pseudonym = NewPseudonym(env.Conf.Privacy.PseudonymKey, input.Nickname)
+		report.Pseudonym = params.Pseudonym
+	case "delete":
+		params.Delete = true
+	default:
+		return ErasureReportOut{}, errors.BadRequestf("invalid action %s", input.Action)
+	}
+
+	if input.From != nil {
+		params.From = *input.From
+	}
+	if input.To != nil {
+		params.To = *input.To
+	}
+
+	s := StoryService{DB: env.DB}
+	erased, err := s.Erase(params)
+	if errors.IsNotFound(err) {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Failed to erase player.")
+		return ErasureReportOut{}, err
+	} else if err != nil {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("Failed to erase player.")
+		return ErasureReportOut{}, err
+	}
+
+	for _, story := range erased {
+		report.Stories = append(report.Stories, ErasedStoryOut(story))
+	}
+
+	storyLogger.WithFields(log.Fields{
+		"stories": len(report.Stories),
+	}).Info("Erased player.")
+	return report, nil
+}
+
+// maskNicknames replaces the nicknames in the story with Player 1, Player 2 ..., in the order they first appear. So a
+// player has the same name throughout the story.
+func maskNicknames(story StoryInOut) StoryInOut {
+	masked := map[string]string{}
+	mask := func(nickname string) string {
+		if nickname == "" {
+			return ""
+		} else if _, ok := masked[nickname]; !ok {
+			masked[nickname] = fmt.Sprintf("Player %d", len(masked)+1)
+		}
+		return masked[nickname]
+	}
+
+	story.Nickname = mask(story.Nickname)
+	for i := range story.Quibly {
+		story.Quibly[i].Nickname = mask(story.Quibly[i].Nickname)
+	}
+
+	for i := range story.FibbingIt {
+		story.FibbingIt[i].Nickname = mask(story.FibbingIt[i].Nickname)
+	}
+
+	// Players answer with each other's nicknames in some rounds, i.e. who is most likely to, so those are masked too.
+	for i := range story.FibbingIt {
+		if nickname, ok := masked[story.FibbingIt[i].Answer]; ok {
+			story.FibbingIt[i].Answer = nickname
+		}
+	}
+
+	if story.Results != nil {
+		results := *story.Results
+		results.Winners = []string{}
+		for _, winner := range story.Results.Winners {
+			results.Winners = append(results.Winners, mask(winner))
+		}
+
+		results.Ranks = []AnswerRankOut{}
+		for _, rank := range story.Results.Ranks {
+			rank.Nickname = mask(rank.Nickname)
+			results.Ranks = append(results.Ranks, rank)
+		}
+		story.Results = &results
+	}
+
+	return story
+}
+
+func (env *StoryAPI) GetStoryTranscript(c *gin.Context, input *StoryTranscriptInput) error {
+	storyLogger := env.Logger.WithFields(log.Fields{
+		"story_id":  input.StoryID,
+		"game_name": input.GameName,
+		"format":    input.Format,
+	})
+	storyLogger.Debug("Trying to get story transcript.")
+
+	game, err := GetGame(input.GameName)
+	if err != nil {
+		return errors.NotFoundf("the game %s", input.GameName)
+	}
+
+	s := StoryService{DB: env.DB}
+	story, err := s.Get(input.StoryID, input.GameName)
+	if err != nil {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn(("Story does not exist."))
+		return errors.NotFoundf("the story %s", input.StoryID)
+	}
+
+	options := env.imageOptions()
+
+	transcript, err := game.NewTranscript(story, options)
+	if err != nil {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("Failed to create transcript.")
+		return err
+	}
+
+	if input.Format == "html" {
+		page, err := transcript.RenderHTML()
+		if err != nil {
+			storyLogger.WithFields(log.Fields{
+				"err": err,
+			}).Error("Failed to render transcript.")
+			return err
+		}
+
+		c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+		return nil
+	}
+
+	c.Data(http.StatusOK, "text/markdown; charset=utf-8", transcript.RenderMarkdown())
+	return nil
+}
+
+func (env *StoryAPI) imageOptions() ImageOptions {
+	return ImageOptions{
+		Width:       env.Conf.Drawing.Width,
+		Height:      env.Conf.Drawing.Height,
+		StrokeWidth: env.Conf.Drawing.StrokeWidth,
+		Background:  env.Conf.Drawing.Background,
+	}
+}
+
 func (env *StoryAPI) DeleteStory(_ *gin.Context, params *CurrentStoryInput) error {
 	storyLogger := env.Logger.WithFields(log.Fields{
 		"story_id":  params.StoryID,
@@ -114,3 +580,209 @@ func (env *StoryAPI) DeleteStory(_ *gin.Context, params *CurrentStoryInput) erro
 
 	return nil
 }
+
+func (env *StoryAPI) PurgeStories(_ *gin.Context, input *PurgeStoriesInput) (PurgeStoriesOut, error) {
+	storyLogger := env.Logger.WithFields(log.Fields{
+		"game_name": input.GameName,
+		"before":    input.Before,
+	})
+	storyLogger.Debug("Trying to purge stories.")
+
+	if _, err := GetGame(input.GameName); err != nil {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Game does not exist.")
+		return PurgeStoriesOut{}, errors.NotFoundf("the game %s", input.GameName)
+	} else if input.Before.IsZero() {
+		return PurgeStoriesOut{}, errors.BadRequestf("missing before time")
+	}
+
+	s := StoryService{DB: env.DB}
+	deleted, err := s.Purge(input.GameName, input.Before)
+	if err != nil {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("Failed to purge stories.")
+		return PurgeStoriesOut{}, err
+	}
+
+	storyLogger.WithFields(log.Fields{
+		"deleted": deleted,
+	}).Info("Purged stories.")
+	return PurgeStoriesOut{Deleted: deleted}, nil
+}
+
+func (env *StoryAPI) GetBestAnswers(_ *gin.Context, input *BestAnswersInput) (BestAnswersOut, error) {
+	storyLogger := env.Logger.WithFields(log.Fields{
+		"game_name": input.GameName,
+		"nickname":  input.Nickname,
+		"limit":     input.Limit,
+	})
+	storyLogger.Debug("Trying to get best answers.")
+
+	s := StoryService{DB: env.DB}
+	answers, err := s.BestAnswers(input.GameName, input.Nickname, input.Limit)
+	if errors.IsNotFound(err) || errors.IsBadRequest(err) {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Failed to get best answers.")
+		return BestAnswersOut{}, err
+	} else if err != nil {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("Failed to get best answers.")
+		return BestAnswersOut{}, err
+	}
+
+	bestAnswers := BestAnswersOut{Answers: []PlayerAnswerOut{}}
+	for _, answer := range answers {
+		answerOut := PlayerAnswerOut{
+			StoryID:       answer.StoryID,
+			Question:      answer.Question,
+			Round:         answer.Round,
+			AnswerRankOut: AnswerRankOut(answer.ScoredAnswer),
+		}
+
+		if !answer.CreatedAt.IsZero() {
+			createdAt := answer.CreatedAt
+			answerOut.CreatedAt = &createdAt
+		}
+		bestAnswers.Answers = append(bestAnswers.Answers, answerOut)
+	}
+
+	return bestAnswers, nil
+}
+
+func (env *StoryAPI) LikeStory(_ *gin.Context, params *CurrentStoryInput) (LikesOut, error) {
+	storyLogger := env.Logger.WithFields(log.Fields{
+		"story_id":  params.StoryID,
+		"game_name": params.GameName,
+	})
+	storyLogger.Debug("Trying to like story.")
+
+	s := StoryService{DB: env.DB}
+	likes, err := s.Like(params.StoryID, params.GameName)
+	if errors.IsNotFound(err) {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Story does not exist.")
+		return LikesOut{}, err
+	} else if err != nil {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("Failed to like story.")
+		return LikesOut{}, err
+	}
+
+	return LikesOut{Likes: likes}, nil
+}
+
+func (env *StoryAPI) UnlikeStory(_ *gin.Context, params *CurrentStoryInput) (LikesOut, error) {
+	storyLogger := env.Logger.WithFields(log.Fields{
+		"story_id":  params.StoryID,
+		"game_name": params.GameName,
+	})
+	storyLogger.Debug("Trying to unlike story.")
+
+	s := StoryService{DB: env.DB}
+	likes, err := s.Unlike(params.StoryID, params.GameName)
+	if errors.IsNotFound(err) {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Story does not exist.")
+		return LikesOut{}, err
+	} else if err != nil {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("Failed to unlike story.")
+		return LikesOut{}, err
+	}
+
+	return LikesOut{Likes: likes}, nil
+}
+
+func (env *StoryAPI) FeatureStory(_ *gin.Context, input *FeatureStoryInput) (StoryInOut, error) {
+	storyLogger := env.Logger.WithFields(log.Fields{
+		"story_id":  input.StoryID,
+		"game_name": input.GameName,
+		"featured":  input.Featured,
+	})
+	storyLogger.Debug("Trying to feature story.")
+
+	s := StoryService{DB: env.DB}
+	story, err := s.Feature(input.StoryID, input.GameName, input.Featured)
+	if errors.IsNotFound(err) {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Story does not exist.")
+		return StoryInOut{}, err
+	} else if err != nil {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("Failed to feature story.")
+		return StoryInOut{}, err
+	}
+
+	return env.newAPIStory(story)
+}
+
+// GetHighlights gets the featured stories of a game and its top stories, the ones with the most likes that were added
+// in the time range. The range is the week before `to`, or the last week, unless `from` is given.
+func (env *StoryAPI) GetHighlights(_ *gin.Context, input *HighlightsInput) (HighlightsOut, error) {
+	storyLogger := env.Logger.WithFields(log.Fields{
+		"game_name": input.GameName,
+		"from":      input.From,
+		"to":        input.To,
+		"limit":     input.Limit,
+	})
+	storyLogger.Debug("Trying to get highlights.")
+
+	params, err := newSearchParams(&ListStoriesInput{From: input.From, To: input.To})
+	if err != nil {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Invalid time range.")
+		return HighlightsOut{}, err
+	}
+
+	if params.From.IsZero() && params.To.IsZero() {
+		params.From = time.Now().UTC().AddDate(0, 0, -7)
+	} else if params.From.IsZero() {
+		params.From = params.To.AddDate(0, 0, -7)
+	}
+
+	s := StoryService{DB: env.DB}
+	featured, top, err := s.Highlights(input.GameName, params.From, params.To, input.Limit)
+	if errors.IsNotFound(err) || errors.IsBadRequest(err) {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Warn("Failed to get highlights.")
+		return HighlightsOut{}, err
+	} else if err != nil {
+		storyLogger.WithFields(log.Fields{
+			"err": err,
+		}).Error("Failed to get highlights.")
+		return HighlightsOut{}, err
+	}
+
+	highlights := HighlightsOut{Featured: []StoryOut{}, Top: []StoryOut{}}
+	for _, story := range featured {
+		storyOut, err := env.newAPIStory(story)
+		if err != nil {
+			storyLogger.Errorf("Failed to convert Story %v", err)
+			return HighlightsOut{}, err
+		}
+		highlights.Featured = append(highlights.Featured, StoryOut{ID: story.ID, StoryInOut: storyOut})
+	}
+
+	for _, story := range top {
+		storyOut, err := env.newAPIStory(story)
+		if err != nil {
+			storyLogger.Errorf("Failed to convert Story %v", err)
+			return HighlightsOut{}, err
+		}
+		highlights.Top = append(highlights.Top, StoryOut{ID: story.ID, StoryInOut: storyOut})
+	}
+
+	return highlights, nil
+}
@@ -1,16 +1,46 @@
 package story
 
 import (
+	"time"
+
 	"gitlab.com/banter-bus/banter-bus-management-api/internal"
 )
 
 type StoryInOut struct {
-	Question string `json:"question"`
-	Round    string `json:"round,omitempty"`
-	Nickname string `json:"nickname,omitempty"`
+	Question   string              `json:"question"`
+	Round      string              `json:"round,omitempty"`
+	Nickname   string              `json:"nickname,omitempty"`
+	CreatedAt  *time.Time          `json:"created_at,omitempty" description:"When the story was added, this is set by the API."`
+	Metadata   *StoryMetadataInOut `json:"metadata,omitempty"   description:"Optional information about the game the story is from."`
+	Results    *StoryResultsOut    `json:"results,omitempty"    description:"The outcome of the story, computed by the API from the votes."`
+	InProgress bool                `json:"in_progress,omitempty" description:"Whether answers can still be added to the story, until it is finalised. A story in progress can be added without any answers."`
+	Likes      int                 `json:"likes,omitempty"      description:"The number of likes of the story, this is set by the API." example:"3"`
+	Featured   bool                `json:"featured,omitempty"   description:"Whether the story is featured, this is set by the API."`
 	StoryAnswersInOut
 }
 
+type StoryResultsOut struct {
+	Winners    []string        `json:"winners"     description:"The players with the most votes, there is no winner if nobody voted."`
+	Tie        bool            `json:"tie"         description:"Whether more than one player has the most votes."`
+	TotalVotes int             `json:"total_votes" description:"The number of votes for all the answers."                     example:"12"`
+	Ranks      []AnswerRankOut `json:"ranks"       description:"The answers, the ones with the most votes first."`
+}
+
+type AnswerRankOut struct {
+	Nickname string  `json:"nickname" description:"The nickname of the player."                           example:"Majiy"`
+	Answer   string  `json:"answer"   description:"The answer of the player."                             example:"a bike"`
+	Votes    int     `json:"votes"    description:"The number of votes for the answer."                   example:"9"`
+	Rank     int     `json:"rank"     description:"The rank of the answer, ties have the same rank."      example:"1"`
+	Share    float64 `json:"share"    description:"The fraction of all the votes that went to the answer." example:"0.75"`
+}
+
+type StoryMetadataInOut struct {
+	RoomCode    string `json:"room_code,omitempty"    description:"The code of the room the game was played in."    example:"ABCD"`
+	Language    string `json:"language,omitempty"     description:"The language the game was played in."            example:"en"`
+	PlayerCount int    `json:"player_count,omitempty" description:"The number of players in the game."              example:"4"`
+	QuestionID  string `json:"question_id,omitempty"  description:"The ID of the question the story is an answer to." example:"4d18ac45-8034-4f8e-b636-cf730b17e51a"`
+}
+
 type StoryAnswersInOut struct {
 	Drawlosseum DrawlosseumAnswersInOut `json:"drawlosseum,omitempty"`
 	Quibly      QuiblyAnswersInOut      `json:"quibly,omitempty"`
@@ -45,7 +75,144 @@ type CurrentStoryInput struct {
 	StoryIDParams
 }
 
+type StoryImageInput struct {
+	CurrentStoryInput
+	Format string `query:"format" description:"The format of the image." enum:"svg,png" default:"svg"`
+}
+
+type StoryTranscriptInput struct {
+	CurrentStoryInput
+	Format string `query:"format" description:"The format of the transcript." enum:"markdown,html" default:"markdown"`
+}
+
 type NewStoryInput struct {
 	internal.GameParams
 	StoryInOut
 }
+
+type StoryOut struct {
+	ID string `json:"id" description:"The id for the story." example:"2b45f6c6d8be4d139fc62f821c925774"`
+	StoryInOut
+}
+
+type StoriesOut struct {
+	Stories []StoryOut `json:"stories" description:"A page of stories."`
+	Cursor  string     `json:"cursor"  description:"The cursor of the next page, empty if this is the last page."`
+}
+
+type ListStoriesInput struct {
+	internal.GameParams
+	Round    string `query:"round"    description:"Only get stories from this round."                                    example:"opinion"`
+	Nickname string `query:"nickname" description:"Only get stories with this player, as the story's or an answer's."   example:"Majiy"`
+	Question string `query:"question" description:"Only get stories for this question."                                 example:"What is the best bike?"`
+	From     string `query:"from"     description:"Only get stories added at or after this time (RFC 3339)."             example:"2021-06-01T00:00:00Z"`
+	To       string `query:"to"       description:"Only get stories added before this time (RFC 3339)."                  example:"2021-07-01T00:00:00Z"`
+	Sort     string `query:"sort"     description:"The order of the stories, only quibly stories can be sorted by votes." enum:"newest,votes" default:"newest"`
+	Limit    int64  `query:"limit"    description:"The number of stories to retrieve."                                   default:"10"             validate:"gte=1,lte=100"`
+	Cursor   string `query:"cursor"   description:"The cursor returned with the previous page."`
+}
+
+type AppendAnswersInput struct {
+	CurrentStoryInput
+	StoryAnswersInOut
+}
+
+type BestAnswersInput struct {
+	internal.GameParams
+	Nickname string `path:"nickname" description:"The nickname of the player."              example:"Majiy"`
+	Limit    int64  `query:"limit"   description:"The number of answers to retrieve." default:"10"          validate:"gte=1,lte=100"`
+}
+
+type BestAnswersOut struct {
+	Answers []PlayerAnswerOut `json:"answers" description:"The answers of the player, the ones with the most votes first."`
+}
+
+type PlayerAnswerOut struct {
+	StoryID   string     `json:"story_id"             description:"The id of the story the answer is from." example:"2b45f6c6d8be4d139fc62f821c925774"`
+	Question  string     `json:"question"             description:"The question of the story."              example:"What is the best bike?"`
+	Round     string     `json:"round,omitempty"      description:"The round of the story."                 example:"pair"`
+	CreatedAt *time.Time `json:"created_at,omitempty" description:"When the story was added."`
+	AnswerRankOut
+}
+
+type ShareStoryIn struct {
+	ExpiresAt     *time.Time `json:"expires_at,omitempty" description:"When the share link expires, it never expires if not set."`
+	MaskNicknames bool       `json:"mask_nicknames"       description:"Whether to replace the nicknames of the players, i.e. Player 1."`
+}
+
+type ShareStoryInput struct {
+	CurrentStoryInput
+	ShareStoryIn
+}
+
+type ShareOut struct {
+	Token     string     `json:"token"                description:"The token of the share link."            example:"q0Hn4dC7bkq3o3pJ0oXq3H7d7J1S4gXc0m3cN9x2sYk"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" description:"When the share link expires, not set if it never expires."`
+}
+
+type ShareTokenParams struct {
+	Token string `description:"The token of the share link." example:"q0Hn4dC7bkq3o3pJ0oXq3H7d7J1S4gXc0m3cN9x2sYk" path:"token"`
+}
+
+type RevokeShareInput struct {
+	CurrentStoryInput
+	ShareTokenParams
+}
+
+type ErasePlayerIn struct {
+	Nickname string     `json:"nickname"            description:"The nickname of the player to erase."                       example:"Majiy"  validate:"required"`
+	GameName string     `json:"game_name,omitempty" description:"Only erase the player from the stories of this game."       example:"quibly"`
+	From     *time.Time `json:"from,omitempty"      description:"Only erase the player from stories added at or after this time."`
+	To       *time.Time `json:"to,omitempty"        description:"Only erase the player from stories added before this time."`
+	Action   string     `json:"action,omitempty"    description:"Whether to redact the nickname or delete the stories, redact by default." example:"redact"`
+}
+
+type ErasureReportOut struct {
+	Action    string           `json:"action"              description:"What was done to the stories."                        example:"redact"`
+	Pseudonym string           `json:"pseudonym,omitempty" description:"What the nickname was replaced with, if it was redacted." example:"player-3f9a1c0b7d2e"`
+	Stories   []ErasedStoryOut `json:"stories"             description:"The stories the player was erased from."`
+}
+
+type ErasedStoryOut struct {
+	GameName string `json:"game_name" description:"The game the story is from."                      example:"quibly"`
+	StoryID  string `json:"story_id"  description:"The id of the story."                             example:"2b45f6c6d8be4d139fc62f821c925774"`
+	Mentions int    `json:"mentions"  description:"How many times the player's nickname was in the story." example:"2"`
+}
+
+type LikesOut struct {
+	Likes int `json:"likes" description:"The number of likes of the story." example:"3"`
+}
+
+type FeatureStoryIn struct {
+	Featured bool `json:"featured" description:"Whether to feature the story."`
+}
+
+type FeatureStoryInput struct {
+	CurrentStoryInput
+	FeatureStoryIn
+}
+
+type HighlightsInput struct {
+	internal.GameParams
+	From  string `query:"from"  description:"Top stories are added at or after this time (RFC 3339), a week before to by default." example:"2021-06-01T00:00:00Z"`
+	To    string `query:"to"    description:"Top stories are added before this time (RFC 3339)."                         example:"2021-07-01T00:00:00Z"`
+	Limit int64  `query:"limit" description:"The number of featured and of top stories to retrieve."        default:"10"                  validate:"gte=1,lte=100"`
+}
+
+type HighlightsOut struct {
+	Featured []StoryOut `json:"featured" description:"The featured stories, newest first."`
+	Top      []StoryOut `json:"top"      description:"The stories with the most likes added in the time range."`
+}
+
+type PurgeStoriesIn struct {
+	Before time.Time `json:"before" description:"Stories added before this time are deleted." validate:"required"`
+}
+
+type PurgeStoriesInput struct {
+	internal.GameParams
+	PurgeStoriesIn
+}
+
+type PurgeStoriesOut struct {
+	Deleted int `json:"deleted" description:"The number of stories deleted." example:"12"`
+}
@@ -0,0 +1,144 @@
+package story
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/pkg/errors"
+	driverbson "go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// compactDrawing is how DrawlosseumAnswers are stored in the database. Segments that join up and have the same color
+// are grouped into a stroke, so each point is only stored once. The coordinates of a stroke are stored as the bits of
+// their float32 value, so they are exact, and delta encoded as zigzag varints, i.e. x0, y0, x1 - x0, y1 - y0 ...
+//
+// Stories added before drawings were compacted store the answers as a list of segments, SetBSON accepts both formats.
+type compactDrawing struct {
+	Strokes []compactStroke `bson:"strokes"`
+}
+
+type compactStroke struct {
+	Color  string `bson:"color"`
+	Points []byte `bson:"points"`
+}
+
+// MarshalBSONValue stores the drawing in the compact format.
+func (d DrawlosseumAnswers) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	drawing := compactDrawing{Strokes: []compactStroke{}}
+
+	var points []int64
+	var lastEnd [2]int64
+	for i, segment := range d {
+		start, end := pointBits(segment.Start), pointBits(segment.End)
+		if i == 0 || segment.Color != d[i-1].Color || start != lastEnd {
+			if i > 0 {
+				drawing.Strokes[len(drawing.Strokes)-1].Points = encodePoints(points)
+			}
+			drawing.Strokes = append(drawing.Strokes, compactStroke{Color: segment.Color})
+			points = []int64{start[0], start[1]}
+		}
+
+		points = append(points, end[0], end[1])
+		lastEnd = end
+	}
+
+	if len(d) > 0 {
+		drawing.Strokes[len(drawing.Strokes)-1].Points = encodePoints(points)
+	}
+
+	return driverbson.MarshalValue(drawing)
+}
+
+// SetBSON reads the drawing in either the compact format or as a list of segments.
+func (d *DrawlosseumAnswers) SetBSON(raw bson.Raw) error {
+	if raw.Kind == byte(bsontype.Array) {
+		var segments []CaertsianCoordinateColor
+		err := raw.Unmarshal(&segments)
+		*d = segments
+		return err
+	}
+
+	var drawing compactDrawing
+	err := raw.Unmarshal(&drawing)
+	if err != nil {
+		return err
+	}
+
+	segments := DrawlosseumAnswers{}
+	for _, stroke := range drawing.Strokes {
+		points, err := decodePoints(stroke.Points)
+		if err != nil {
+			return err
+		}
+
+		for i := 2; i+1 < len(points); i += 2 {
+			start, err := newDrawingPoint(points[i-2], points[i-1])
+			if err != nil {
+				return err
+			}
+
+			end, err := newDrawingPoint(points[i], points[i+1])
+			if err != nil {
+				return err
+			}
+
+			segments = append(segments, CaertsianCoordinateColor{Start: start, End: end, Color: stroke.Color})
+		}
+	}
+
+	*d = segments
+	return nil
+}
+
+func pointBits(point DrawingPoint) [2]int64 {
+	return [2]int64{int64(math.Float32bits(point.X)), int64(math.Float32bits(point.Y))}
+}
+
+// newDrawingPoint reads a point stored as the bits of its coordinates.
+func newDrawingPoint(x int64, y int64) (DrawingPoint, error) {
+	if x < 0 || x > math.MaxUint32 || y < 0 || y > math.MaxUint32 {
+		return DrawingPoint{}, errors.Errorf("invalid point in drawing")
+	}
+
+	return DrawingPoint{X: math.Float32frombits(uint32(x)), Y: math.Float32frombits(uint32(y))}, nil
+}
+
+func encodePoints(points []int64) []byte {
+	encoded := make([]byte, 0, len(points)*2)
+	buffer := make([]byte, binary.MaxVarintLen64)
+
+	var previousX, previousY int64
+	for i := 0; i+1 < len(points); i += 2 {
+		n := binary.PutVarint(buffer, points[i]-previousX)
+		encoded = append(encoded, buffer[:n]...)
+		n = binary.PutVarint(buffer, points[i+1]-previousY)
+		encoded = append(encoded, buffer[:n]...)
+		previousX, previousY = points[i], points[i+1]
+	}
+
+	return encoded
+}
+
+func decodePoints(encoded []byte) ([]int64, error) {
+	var points []int64
+	var previous [2]int64
+	for len(encoded) > 0 {
+		delta, n := binary.Varint(encoded)
+		if n <= 0 {
+			return nil, errors.Errorf("invalid point in drawing")
+		}
+
+		encoded = encoded[n:]
+		axis := len(points) % 2
+		previous[axis] += delta
+		points = append(points, previous[axis])
+	}
+
+	if len(points)%2 != 0 {
+		return nil, errors.Errorf("drawing has an odd number of coordinates")
+	}
+
+	return points, nil
+}
@@ -2,6 +2,7 @@ package story
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
@@ -9,17 +10,32 @@ import (
 
 	"gitlab.com/banter-bus/banter-bus-management-api/internal"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/core/database"
-	"gitlab.com/banter-bus/banter-bus-management-api/internal/games"
 )
 
-// Story struct to contain information about a user story
+// Story struct to contain information about a user story. Likes and Featured are read from the database but never
+// written with the rest of the story, they are only changed using UpdateStory and Increment so updating a story
+// doesn't overwrite a like added in the meantime. Revision counts how many times the story was updated, see Update.
 type Story struct {
-	GameName string          `bson:"game_name"          json:"game_name"`
-	ID       string          `bson:"id"`
-	Question string          `bson:"question"`
-	Round    string          `bson:"round,omitempty"`
-	Nickname string          `bson:"nickname,omitempty"`
-	Answers  StoryAnswerType `bson:"answers"`
+	GameName   string          `bson:"game_name"          json:"game_name"`
+	ID         string          `bson:"id"`
+	Question   string          `bson:"question"`
+	Round      string          `bson:"round,omitempty"`
+	Nickname   string          `bson:"nickname,omitempty"`
+	Answers    StoryAnswerType `bson:"answers"`
+	CreatedAt  time.Time       `bson:"created_at,omitempty"`
+	Metadata   *StoryMetadata  `bson:"metadata,omitempty"`
+	InProgress bool            `bson:"in_progress"`
+	Revision   int             `bson:"revision,omitempty"`
+	Likes      int             `bson:"-"`
+	Featured   bool            `bson:"-"`
+}
+
+// StoryMetadata is optional information about the game a story is from, it is supplied by the game server.
+type StoryMetadata struct {
+	RoomCode    string `bson:"room_code,omitempty"    json:"room_code,omitempty"`
+	Language    string `bson:"language,omitempty"     json:"language,omitempty"`
+	PlayerCount int    `bson:"player_count,omitempty" json:"player_count,omitempty"`
+	QuestionID  string `bson:"question_id,omitempty"  json:"question_id,omitempty"`
 }
 
 func (story *Story) Add(db database.Database) (bool, error) {
@@ -32,8 +48,23 @@ func (story *Story) Get(db database.Database, filter map[string]interface{}) err
 	return err
 }
 
+// Update saves the story as long as it wasn't updated since it was read, nothing is updated otherwise. So a story that
+// is read, changed and saved never overwrites changes saved by someone else in the meantime. Stories that were never
+// updated have no revision.
 func (story *Story) Update(db database.Database, filter map[string]interface{}) (bool, error) {
-	updated, err := db.Update("story", filter, story)
+	revisionFilter := map[string]interface{}{"revision": story.Revision}
+	if story.Revision == 0 {
+		revisionFilter["revision"] = map[string]interface{}{"$exists": false}
+	}
+	for key, value := range filter {
+		revisionFilter[key] = value
+	}
+
+	story.Revision++
+	updated, err := db.Update("story", revisionFilter, story)
+	if !updated || err != nil {
+		story.Revision--
+	}
 	return updated, err
 }
 
@@ -46,11 +77,17 @@ func (story *Story) Update(db database.Database, filter map[string]interface{})
 // The `story`, is what is returned when we get the `Story` data from the database.
 func (story *Story) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
 	temp := struct {
-		GameName string `json:"game_name" bson:"game_name"`
-		ID       string
-		Question string
-		Round    string
-		Nickname string
+		GameName   string `json:"game_name" bson:"game_name"`
+		ID         string
+		Question   string
+		Round      string
+		Nickname   string
+		CreatedAt  time.Time      `json:"created_at" bson:"created_at"`
+		Metadata   *StoryMetadata `json:"metadata"    bson:"metadata"`
+		InProgress bool           `json:"in_progress" bson:"in_progress"`
+		Revision   int
+		Likes      int
+		Featured   bool
 	}{}
 
 	var answers struct {
@@ -76,11 +113,17 @@ func (story *Story) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
 // UnmarshalJSON works almost the same way as the UnmarshalBSONValue method above.
 func (story *Story) UnmarshalJSON(data []byte) error {
 	temp := struct {
-		GameName string `json:"game_name" bson:"game_name"`
-		ID       string
-		Question string
-		Round    string
-		Nickname string
+		GameName   string `json:"game_name" bson:"game_name"`
+		ID         string
+		Question   string
+		Round      string
+		Nickname   string
+		CreatedAt  time.Time      `json:"created_at" bson:"created_at"`
+		Metadata   *StoryMetadata `json:"metadata"    bson:"metadata"`
+		InProgress bool           `json:"in_progress" bson:"in_progress"`
+		Revision   int
+		Likes      int
+		Featured   bool
 	}{}
 
 	var answers struct {
@@ -104,30 +147,51 @@ func (story *Story) UnmarshalJSON(data []byte) error {
 }
 
 func setStoryFields(temp struct {
-	GameName string `json:"game_name" bson:"game_name"`
-	ID       string
-	Question string
-	Round    string
-	Nickname string
+	GameName   string `json:"game_name" bson:"game_name"`
+	ID         string
+	Question   string
+	Round      string
+	Nickname   string
+	CreatedAt  time.Time      `json:"created_at" bson:"created_at"`
+	Metadata   *StoryMetadata `json:"metadata"    bson:"metadata"`
+	InProgress bool           `json:"in_progress" bson:"in_progress"`
+	Revision   int
+	Likes      int
+	Featured   bool
 }, story *Story) {
 	story.GameName = temp.GameName
 	story.ID = temp.ID
 	story.Question = temp.Question
 	story.Round = temp.Round
 	story.Nickname = temp.Nickname
+	story.CreatedAt = temp.CreatedAt.UTC()
+	story.Metadata = temp.Metadata
+	story.InProgress = temp.InProgress
+	story.Revision = temp.Revision
+	story.Likes = temp.Likes
+	story.Featured = temp.Featured
 }
 
 func getStoryType(gameName string) (StoryAnswerType, error) {
-	switch gameName {
-	case games.DRAWLOSSEUM:
-		return &DrawlosseumAnswers{}, nil
-	case games.QUIBLY:
-		return &QuiblyAnswers{}, nil
-	case games.FIBBINGIT:
-		return &FibbingItAnswers{}, nil
-	default:
+	game, err := GetGame(gameName)
+	if err != nil {
 		return nil, errors.Errorf("unknown game name %s", gameName)
 	}
+
+	return game.NewAnswers(), nil
+}
+
+// UpdateStory changes only the given fields of a story.
+type UpdateStory map[string]interface{}
+
+func (story *UpdateStory) Add(db database.Database, filter map[string]interface{}) (bool, error) {
+	updated, err := db.UpdateObject("story", filter, story)
+	return updated, err
+}
+
+func (story *UpdateStory) Remove(db database.Database, filter map[string]interface{}) (bool, error) {
+	removed, err := db.RemoveObject("story", filter, story)
+	return removed, err
 }
 
 type FibbingItAnswer struct {
@@ -149,6 +213,60 @@ type QuiblyAnswers []QuiblyAnswer
 
 func (q QuiblyAnswers) NewAnswer() {}
 
+// nicknameRenamer is implemented by the answers of games where each answer has the nickname of the player.
+type nicknameRenamer interface {
+	renameNickname(nickname string, newNickname string) int
+}
+
+// renameNickname also renames answers that are the nickname, as players answer with each other's nicknames in some
+// rounds, i.e. who is most likely to.
+func (f *FibbingItAnswers) renameNickname(nickname string, newNickname string) int {
+	renamed := 0
+	for i := range *f {
+		if (*f)[i].Nickname == nickname {
+			(*f)[i].Nickname = newNickname
+			renamed++
+		}
+
+		if (*f)[i].Answer == nickname {
+			(*f)[i].Answer = newNickname
+			renamed++
+		}
+	}
+
+	return renamed
+}
+
+func (q *QuiblyAnswers) renameNickname(nickname string, newNickname string) int {
+	renamed := 0
+	for i := range *q {
+		if (*q)[i].Nickname == nickname {
+			(*q)[i].Nickname = newNickname
+			renamed++
+		}
+	}
+
+	return renamed
+}
+
+// ScoredAnswer is an answer ranked against the other answers of its story by its votes.
+type ScoredAnswer struct {
+	Nickname string
+	Answer   string
+	Votes    int
+	Rank     int
+	Share    float64
+}
+
+// PlayerAnswer is a scored answer of a player, with the story it is from.
+type PlayerAnswer struct {
+	StoryID   string
+	Question  string
+	Round     string
+	CreatedAt time.Time
+	ScoredAnswer
+}
+
 type DrawlosseumAnswers []CaertsianCoordinateColor
 
 func (d DrawlosseumAnswers) NewAnswer() {}
@@ -165,6 +283,11 @@ func (stories *Stories) Get(db database.Database, filter map[string]interface{})
 	return err
 }
 
+func (stories *Stories) GetPage(db database.Database, filter map[string]interface{}, page database.Page) (string, error) {
+	cursor, err := db.GetPage("story", filter, page, stories)
+	return cursor, err
+}
+
 func (stories *Stories) GetWithLimit(db database.Database, filter map[string]interface{}, limit int64) error {
 	return nil
 }
@@ -185,3 +308,73 @@ func (stories Stories) ToInterface() []interface{} {
 type StoryAnswerType interface {
 	NewAnswer()
 }
+
+// Transcript is a story in a form that can be read by people, see RenderMarkdown and RenderHTML.
+type Transcript struct {
+	Question string
+	Round    string
+	Nickname string
+	Entries  []TranscriptEntry
+	Drawing  []byte
+}
+
+// TranscriptEntry is the answer of a player, with its votes for games where answers are voted on.
+type TranscriptEntry struct {
+	Nickname string
+	Answer   string
+	Votes    *int
+}
+
+// Share is a link to a story, that can be viewed by anyone with its token until it expires or is revoked.
+type Share struct {
+	Token         string     `bson:"token"`
+	GameName      string     `bson:"game_name"`
+	StoryID       string     `bson:"story_id"`
+	MaskNicknames bool       `bson:"mask_nicknames"`
+	CreatedAt     time.Time  `bson:"created_at"`
+	ExpiresAt     *time.Time `bson:"expires_at,omitempty"`
+}
+
+func (share *Share) Add(db database.Database) (bool, error) {
+	inserted, err := db.Insert("share", share)
+	return inserted, err
+}
+
+func (share *Share) Get(db database.Database, filter map[string]interface{}) error {
+	err := db.Get("share", filter, share)
+	return err
+}
+
+func (share *Share) Update(db database.Database, filter map[string]interface{}) (bool, error) {
+	updated, err := db.Update("share", filter, share)
+	return updated, err
+}
+
+type SearchParams struct {
+	Round    string
+	Nickname string
+	Question string
+	From     time.Time
+	To       time.Time
+	SortBy   string
+	Limit    int64
+	Cursor   string
+}
+
+// ErasureParams are which stories to erase a player from and how, either by replacing their nickname with the
+// pseudonym or by deleting the stories.
+type ErasureParams struct {
+	Nickname  string
+	GameName  string
+	From      time.Time
+	To        time.Time
+	Delete    bool
+	Pseudonym string
+}
+
+// ErasedStory is a story a player was erased from, with how many times the player's nickname was in it.
+type ErasedStory struct {
+	GameName string
+	StoryID  string
+	Mentions int
+}
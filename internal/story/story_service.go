@@ -1,10 +1,18 @@
 package story
 
 import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/juju/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/core/database"
 )
@@ -17,6 +25,12 @@ func (s *StoryService) Add(story Story) (string, error) {
 	uuidWithHyphen := uuid.New()
 	uuid := strings.ReplaceAll(uuidWithHyphen.String(), "-", "")
 	story.ID = uuid
+	story.CreatedAt = time.Now().UTC().Truncate(time.Millisecond)
+
+	err := s.validateQuestion(story)
+	if err != nil {
+		return "", err
+	}
 
 	inserted, err := story.Add(s.DB)
 	if !inserted || err != nil {
@@ -26,6 +40,39 @@ func (s *StoryService) Add(story Story) (string, error) {
 	return uuid, nil
 }
 
+// validateQuestion checks the question the story is about exists for the game, and that it has been translated into
+// the language of the story. Stories don't have to reference a question.
+func (s *StoryService) validateQuestion(story Story) error {
+	if story.Metadata == nil || story.Metadata.QuestionID == "" {
+		return nil
+	}
+
+	filter := map[string]interface{}{
+		"id":        story.Metadata.QuestionID,
+		"game_name": story.GameName,
+	}
+
+	if story.Metadata.Language != "" {
+		filter["content."+story.Metadata.Language] = map[string]interface{}{"$exists": true}
+	}
+
+	count, err := s.DB.Count("question", filter)
+	if err != nil {
+		return errors.Errorf("failed to get question %s %v", story.Metadata.QuestionID, err)
+	} else if count == 0 && story.Metadata.Language != "" {
+		return errors.BadRequestf(
+			"question %s in language %s does not exist for game %s",
+			story.Metadata.QuestionID,
+			story.Metadata.Language,
+			story.GameName,
+		)
+	} else if count == 0 {
+		return errors.BadRequestf("question %s does not exist for game %s", story.Metadata.QuestionID, story.GameName)
+	}
+
+	return nil
+}
+
 func (s *StoryService) Get(storyID string, gameName string) (Story, error) {
 	filter := map[string]interface{}{
 		"id":        storyID,
@@ -41,6 +88,64 @@ func (s *StoryService) Get(storyID string, gameName string) (Story, error) {
 	return story, nil
 }
 
+// List gets a page of the stories of a game, newest first or, for games with votes, sorted by their total votes.
+// The time range uses the time the story was added, see createdWithin.
+func (s *StoryService) List(gameName string, params SearchParams) (Stories, string, error) {
+	answers, err := getStoryType(gameName)
+	if err != nil {
+		return Stories{}, "", errors.NotFoundf("the game %s", gameName)
+	}
+
+	filter := map[string]interface{}{
+		"game_name": gameName,
+	}
+
+	if params.Round != "" {
+		filter["round"] = params.Round
+	}
+	if params.Question != "" {
+		filter["question"] = params.Question
+	}
+
+	conditions := []interface{}{}
+	if params.Nickname != "" {
+		conditions = append(conditions, map[string]interface{}{
+			"$or": []interface{}{
+				map[string]interface{}{"nickname": params.Nickname},
+				map[string]interface{}{"answers.nickname": params.Nickname},
+			},
+		})
+	}
+	if !params.From.IsZero() || !params.To.IsZero() {
+		conditions = append(conditions, createdWithin(params.From, params.To))
+	}
+	if len(conditions) > 0 {
+		filter["$and"] = conditions
+	}
+
+	page := database.Page{Limit: params.Limit, Cursor: params.Cursor}
+	switch params.SortBy {
+	case "", "newest":
+	case "votes":
+		if _, ok := answers.(*QuiblyAnswers); !ok {
+			return Stories{}, "", errors.BadRequestf("stories of game %s cannot be sorted by votes", gameName)
+		}
+		page.SortBy = "answers.votes"
+	default:
+		return Stories{}, "", errors.BadRequestf("invalid sort %s", params.SortBy)
+	}
+
+	stories := Stories{}
+	cursor, err := stories.GetPage(s.DB, filter, page)
+	if err == database.ErrInvalidCursor {
+		return Stories{}, "", errors.BadRequestf("invalid cursor %s", params.Cursor)
+	} else if err != nil {
+		return Stories{}, "", errors.Errorf("failed to get stories %v", err)
+	}
+
+	return stories, cursor, nil
+}
+
 func (s *StoryService) Delete(storyID string, gameName string) error {
 	filter := map[string]interface{}{
 		"id":        storyID,
@@ -55,20 +160,526 @@ func (s *StoryService) Delete(storyID string, gameName string) error {
 	return nil
 }
 
+// Purge deletes the stories of a game that were added before the given time. Stories added before they had a
+// `created_at` use the time from their `_id` instead.
+func (s *StoryService) Purge(gameName string, before time.Time) (int, error) {
+	filter := map[string]interface{}{
+		"game_name": gameName,
+		"$or": []interface{}{
+			map[string]interface{}{"created_at": map[string]interface{}{"$lt": before}},
+			map[string]interface{}{
+				"created_at": map[string]interface{}{"$exists": false},
+				"_id":        map[string]interface{}{"$lt": primitive.NewObjectIDFromTimestamp(before)},
+			},
+		},
+	}
+
+	deleted, err := s.DB.DeleteMany("story", filter)
+	if err != nil {
+		return 0, errors.Errorf("failed to purge stories of game %s %v", gameName, err)
+	}
+
+	return int(deleted), nil
+}
+
+// createdWithin matches the stories added within a time range, either end can be zero to leave it open. Like Purge,
+// stories added before they had a `created_at` use the time from their `_id` instead.
+func createdWithin(from time.Time, to time.Time) map[string]interface{} {
+	createdAt := map[string]interface{}{}
+	id := map[string]interface{}{}
+	if !from.IsZero() {
+		createdAt["$gte"] = from
+		id["$gte"] = primitive.NewObjectIDFromTimestamp(from)
+	}
+	if !to.IsZero() {
+		createdAt["$lt"] = to
+		id["$lt"] = primitive.NewObjectIDFromTimestamp(to)
+	}
+
+	return map[string]interface{}{
+		"$or": []interface{}{
+			map[string]interface{}{"created_at": createdAt},
+			map[string]interface{}{
+				"created_at": map[string]interface{}{"$exists": false},
+				"_id":        id,
+			},
+		},
+	}
+}
+
+// Append adds answers to a story that is still in progress, the game validates the answers and how they are added.
+func (s *StoryService) Append(storyID string, gameName string, answers StoryAnswersInOut) (Story, error) {
+	game, err := GetGame(gameName)
+	if err != nil {
+		return Story{}, err
+	}
+
+	return s.updateInProgress(storyID, gameName, func(story Story) (Story, error) {
+		return game.AppendAnswers(story, answers)
+	})
+}
+
+// Finalise marks a story as complete, after which no more answers can be added to it.
+func (s *StoryService) Finalise(storyID string, gameName string) (Story, error) {
+	return s.updateInProgress(storyID, gameName, func(story Story) (Story, error) {
+		story.InProgress = false
+		return story, nil
+	})
+}
+
+func (s *StoryService) getInProgress(storyID string, gameName string) (Story, error) {
+	story, err := s.Get(storyID, gameName)
+	if err != nil {
+		return Story{}, errors.NotFoundf("the story %s", storyID)
+	} else if !story.InProgress {
+		return Story{}, errors.BadRequestf("the story %s is already complete", storyID)
+	}
+
+	return story, nil
+}
+
+// maxUpdateAttempts is how many times a change to a story in progress is tried, each player of a game can change the
+// story at the same time so every one of them has to be able to retry.
+const maxUpdateAttempts = 16
+
+// updateInProgress changes the story and saves it as long as it is still in progress, so answers cannot be added to a
+// story that was finalised in the meantime. If someone else updated the story in the meantime, the change is applied
+// again to their story, so concurrent answers and votes are never lost.
+func (s *StoryService) updateInProgress(
+	storyID string,
+	gameName string,
+	change func(story Story) (Story, error),
+) (Story, error) {
+	filter := map[string]interface{}{
+		"id":          storyID,
+		"game_name":   gameName,
+		"in_progress": true,
+	}
+
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		story, err := s.getInProgress(storyID, gameName)
+		if err != nil {
+			return Story{}, err
+		}
+
+		story, err = change(story)
+		if err != nil {
+			return Story{}, err
+		}
+
+		updated, err := story.Update(s.DB, filter)
+		if err != nil {
+			return Story{}, errors.Errorf("failed to update story %s %v", storyID, err)
+		} else if updated {
+			return story, nil
+		}
+	}
+
+	return Story{}, errors.Errorf("failed to update story %s, it was updated by someone else every time", storyID)
+}
+
+// Like adds a like to a story and returns how many likes it has.
+func (s *StoryService) Like(storyID string, gameName string) (int, error) {
+	filter := map[string]interface{}{
+		"id":        storyID,
+		"game_name": gameName,
+	}
+
+	updated, err := s.DB.Increment("story", filter, "likes", 1)
+	if err != nil {
+		return 0, errors.Errorf("failed to like story %s %v", storyID, err)
+	} else if !updated {
+		return 0, errors.NotFoundf("the story %s", storyID)
+	}
+
+	return s.getLikes(storyID, gameName)
+}
+
+// Unlike removes a like from a story and returns how many likes it has, a story never has fewer than zero likes.
+func (s *StoryService) Unlike(storyID string, gameName string) (int, error) {
+	filter := map[string]interface{}{
+		"id":        storyID,
+		"game_name": gameName,
+		"likes":     map[string]interface{}{"$gt": 0},
+	}
+
+	_, err := s.DB.Increment("story", filter, "likes", -1)
+	if err != nil {
+		return 0, errors.Errorf("failed to unlike story %s %v", storyID, err)
+	}
+
+	return s.getLikes(storyID, gameName)
+}
+
+func (s *StoryService) getLikes(storyID string, gameName string) (int, error) {
+	story, err := s.Get(storyID, gameName)
+	if err != nil {
+		return 0, errors.NotFoundf("the story %s", storyID)
+	}
+
+	return story.Likes, nil
+}
+
+// Feature marks a story as featured, or stops featuring it.
+func (s *StoryService) Feature(storyID string, gameName string, featured bool) (Story, error) {
+	filter := map[string]interface{}{
+		"id":        storyID,
+		"game_name": gameName,
+	}
+
+	update := UpdateStory{"featured": featured}
+	_, err := update.Add(s.DB, filter)
+	if err != nil {
+		return Story{}, errors.Errorf("failed to feature story %s %v", storyID, err)
+	}
+
+	// Nothing is updated if the story was already (not) featured, so whether it exists is checked after.
+	story, err := s.Get(storyID, gameName)
+	if err != nil {
+		return Story{}, errors.NotFoundf("the story %s", storyID)
+	}
+
+	return story, nil
+}
+
+// Highlights gets the featured stories of a game newest first, and the stories with the most likes that were added in
+// the time range, see createdWithin. Stories without any likes are not top stories.
+func (s *StoryService) Highlights(gameName string, from time.Time, to time.Time, limit int64) (Stories, Stories, error) {
+	_, err := GetGame(gameName)
+	if err != nil {
+		return Stories{}, Stories{}, errors.NotFoundf("the game %s", gameName)
+	} else if !to.IsZero() && !from.Before(to) {
+		return Stories{}, Stories{}, errors.BadRequestf("from %s must be before to %s", from, to)
+	}
+
+	featured := Stories{}
+	filter := map[string]interface{}{
+		"game_name": gameName,
+		"featured":  true,
+	}
+
+	_, err = featured.GetPage(s.DB, filter, database.Page{Limit: limit})
+	if err != nil {
+		return Stories{}, Stories{}, errors.Errorf("failed to get featured stories %v", err)
+	}
+
+	top := Stories{}
+	filter = map[string]interface{}{
+		"game_name": gameName,
+		"likes":     map[string]interface{}{"$gt": 0},
+		"$and":      []interface{}{createdWithin(from, to)},
+	}
+
+	_, err = top.GetPage(s.DB, filter, database.Page{Limit: limit, SortBy: "likes"})
+	if err != nil {
+		return Stories{}, Stories{}, errors.Errorf("failed to get top stories %v", err)
+	}
+
+	return featured, top, nil
+}
+
+// BestAnswers gets the answers of a player across all the stories of a game, the ones with the most votes first. Answers
+// with the same number of votes are ordered by their share of the votes and then newest first.
+func (s *StoryService) BestAnswers(gameName string, nickname string, limit int64) ([]PlayerAnswer, error) {
+	game, err := GetGame(gameName)
+	if err != nil {
+		return nil, err
+	}
+
+	scorer, ok := game.(Scorer)
+	if !ok {
+		return nil, errors.BadRequestf("answers of game %s are not voted on", gameName)
+	}
+
+	filter := map[string]interface{}{
+		"game_name":        gameName,
+		"answers.nickname": nickname,
+	}
+
+	stories := Stories{}
+	err = stories.Get(s.DB, filter)
+	if err != nil {
+		return nil, errors.Errorf("failed to get stories %v", err)
+	}
+
+	answers := []PlayerAnswer{}
+	for _, story := range stories {
+		scored, err := scorer.ScoreAnswers(story)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, answer := range scored {
+			if answer.Nickname != nickname {
+				continue
+			}
+
+			answers = append(answers, PlayerAnswer{
+				StoryID:      story.ID,
+				Question:     story.Question,
+				Round:        story.Round,
+				CreatedAt:    story.CreatedAt,
+				ScoredAnswer: answer,
+			})
+		}
+	}
+
+	sort.SliceStable(answers, func(i, j int) bool {
+		if answers[i].Votes != answers[j].Votes {
+			return answers[i].Votes > answers[j].Votes
+		} else if answers[i].Share != answers[j].Share {
+			return answers[i].Share > answers[j].Share
+		}
+		return answers[i].CreatedAt.After(answers[j].CreatedAt)
+	})
+
+	if int64(len(answers)) > limit {
+		answers = answers[:limit]
+	}
+
+	return answers, nil
+}
+
+// Share creates a share link for a story, its token is random so it cannot be guessed. Links without an expiry time
+// last until they are revoked.
+func (s *StoryService) Share(storyID string, gameName string, expiresAt *time.Time, maskNicknames bool) (Share, error) {
+	_, err := s.Get(storyID, gameName)
+	if err != nil {
+		return Share{}, errors.NotFoundf("the story %s", storyID)
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	if expiresAt != nil && !expiresAt.After(now) {
+		return Share{}, errors.BadRequestf("the share link must expire in the future")
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return Share{}, err
+	}
+
+	share := Share{
+		Token:         token,
+		GameName:      gameName,
+		StoryID:       storyID,
+		MaskNicknames: maskNicknames,
+		CreatedAt:     now,
+		ExpiresAt:     expiresAt,
+	}
+
+	inserted, err := share.Add(s.DB)
+	if !inserted || err != nil {
+		return Share{}, errors.Errorf("failed to add share link %v", err)
+	}
+
+	return share, nil
+}
+
+// ResolveShare gets the story a share link is for, expired links are treated as if they don't exist.
+func (s *StoryService) ResolveShare(token string) (Story, Share, error) {
+	share := Share{}
+	err := share.Get(s.DB, map[string]interface{}{"token": token})
+	if err != nil {
+		return Story{}, Share{}, errors.NotFoundf("the share link")
+	} else if share.ExpiresAt != nil && !share.ExpiresAt.After(time.Now()) {
+		return Story{}, Share{}, errors.NotFoundf("the share link")
+	}
+
+	story, err := s.Get(share.StoryID, share.GameName)
+	if err != nil {
+		return Story{}, Share{}, errors.NotFoundf("the story %s", share.StoryID)
+	}
+
+	return story, share, nil
+}
+
+// RevokeShare deletes a share link of a story, so it can no longer be viewed using the link.
+func (s *StoryService) RevokeShare(storyID string, gameName string, token string) error {
+	filter := map[string]interface{}{
+		"token":     token,
+		"story_id":  storyID,
+		"game_name": gameName,
+	}
+
+	deleted, err := s.DB.Delete("share", filter)
+	if err != nil {
+		return errors.Errorf("failed to revoke share link %v", err)
+	} else if !deleted {
+		return errors.NotFoundf("the share link")
+	}
+
+	return nil
+}
+
+func newShareToken() (string, error) {
+	token := make([]byte, 32)
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", errors.Errorf("failed to create share token %v", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// Erase removes a player from every story that mentions their nickname, optionally only for one game or stories added
+// within a time range. Share links of deleted stories are also deleted.
+func (s *StoryService) Erase(params ErasureParams) ([]ErasedStory, error) {
+	conditions := []interface{}{
+		map[string]interface{}{
+			"$or": []interface{}{
+				map[string]interface{}{"nickname": params.Nickname},
+				map[string]interface{}{"answers.nickname": params.Nickname},
+			},
+		},
+	}
+	if !params.From.IsZero() || !params.To.IsZero() {
+		conditions = append(conditions, createdWithin(params.From, params.To))
+	}
+
+	filter := map[string]interface{}{"$and": conditions}
+	if params.GameName != "" {
+		if _, err := GetGame(params.GameName); err != nil {
+			return nil, errors.NotFoundf("the game %s", params.GameName)
+		}
+		filter["game_name"] = params.GameName
+	}
+
+	stories := Stories{}
+	err := stories.Get(s.DB, filter)
+	if err != nil {
+		return nil, errors.Errorf("failed to get stories %v", err)
+	}
+
+	erased := []ErasedStory{}
+	storyIDs := []string{}
+	for _, story := range stories {
+		mentions := 0
+		if story.Nickname == params.Nickname {
+			story.Nickname = params.Pseudonym
+			mentions++
+		}
+
+		if answers, ok := story.Answers.(nicknameRenamer); ok {
+			mentions += answers.renameNickname(params.Nickname, params.Pseudonym)
+		}
+
+		if !params.Delete {
+			storyFilter := map[string]interface{}{
+				"id":        story.ID,
+				"game_name": story.GameName,
+			}
+
+			updated, err := story.Update(s.DB, storyFilter)
+			if !updated || err != nil {
+				return erased, errors.Errorf("failed to redact story %s %v", story.ID, err)
+			}
+		}
+
+		storyIDs = append(storyIDs, story.ID)
+		erased = append(erased, ErasedStory{GameName: story.GameName, StoryID: story.ID, Mentions: mentions})
+	}
+
+	if params.Delete && len(storyIDs) > 0 {
+		idFilter := map[string]interface{}{"$in": storyIDs}
+		_, err = s.DB.DeleteMany("story", map[string]interface{}{"id": idFilter})
+		if err != nil {
+			return nil, errors.Errorf("failed to delete stories %v", err)
+		}
+
+		_, err = s.DB.DeleteMany("share", map[string]interface{}{"story_id": idFilter})
+		if err != nil {
+			return nil, errors.Errorf("failed to delete share links %v", err)
+		}
+	}
+
+	return erased, nil
+}
+
+// NewPseudonym replaces a nickname with one that can't be traced back to it without the key, the same nickname always
+// gets the same pseudonym so redacted stories still make sense.
+func NewPseudonym(key string, nickname string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(nickname))
+	return "player-" + hex.EncodeToString(mac.Sum(nil))[:12]
+}
+
+// CompactDrawings rewrites the drawings of a game that are still stored as a list of segments in the compact format,
+// batchSize stories at a time, it returns how many stories were migrated. Stories that have already been migrated are
+// left alone, so it is safe to run it again.
+func (s *StoryService) CompactDrawings(gameName string, batchSize int64) (int, error) {
+	filter := map[string]interface{}{
+		"game_name":       gameName,
+		"answers.0.start": map[string]interface{}{"$exists": true},
+	}
+
+	migrated := 0
+	page := database.Page{Limit: batchSize}
+	for {
+		stories := Stories{}
+		cursor, err := stories.GetPage(s.DB, filter, page)
+		if err != nil {
+			return migrated, errors.Errorf("failed to get stories to migrate %v", err)
+		}
+
+		for _, story := range stories {
+			storyFilter := map[string]interface{}{
+				"id":        story.ID,
+				"game_name": story.GameName,
+			}
+
+			updated, err := story.Update(s.DB, storyFilter)
+			if !updated || err != nil {
+				return migrated, errors.Errorf("failed to migrate story %s %v", story.ID, err)
+			}
+			migrated++
+		}
+
+		if cursor == "" {
+			return migrated, nil
+		}
+		page.Cursor = cursor
+	}
+}
+
 type Gamer interface {
+	NewAnswers() StoryAnswerType
 	NewStory(story StoryInOut) (Story, error)
 	NewStoryOut(story Story) (StoryInOut, error)
+	AppendAnswers(story Story, answers StoryAnswersInOut) (Story, error)
+	NewTranscript(story Story, options ImageOptions) (Transcript, error)
+}
+
+// ImageRenderer is implemented by the Gamer of games whose stories are drawings, so they can be viewed as an image.
+type ImageRenderer interface {
+	RenderSVG(story Story, options ImageOptions) ([]byte, error)
+	RenderPNG(story Story, options ImageOptions) ([]byte, error)
+}
+
+// Scorer is implemented by the Gamer of games whose answers are voted on, so the answers can be ranked.
+type Scorer interface {
+	ScoreAnswers(story Story) ([]ScoredAnswer, error)
+}
+
+type ImageOptions struct {
+	Width       int
+	Height      int
+	StrokeWidth float64
+	Background  string
+}
+
+var gamers = map[string]Gamer{}
+
+// RegisterGame makes the story converter of a game available through GetGame. Games should be registered through
+// games.Register rather than calling this directly.
+func RegisterGame(name string, gamer Gamer) {
+	gamers[name] = gamer
 }
 
 func GetGame(name string) (Gamer, error) {
-	switch name {
-	case "quibly":
-		return Quibly{}, nil
-	case "fibbing_it":
-		return FibbingIt{}, nil
-	case "drawlosseum":
-		return Drawlosseum{}, nil
-	default:
+	gamer, ok := gamers[name]
+	if !ok {
 		return nil, errors.NotFoundf("Game %s", name)
 	}
+
+	return gamer, nil
 }
@@ -0,0 +1,115 @@
+package story
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"#", `\#`, "<", `\<`, ">", `\>`, "|", `\|`, "\n", " ",
+)
+
+// RenderMarkdown renders the transcript as Markdown, the drawing is embedded as an SVG data URI.
+func (transcript Transcript) RenderMarkdown() []byte {
+	var markdown strings.Builder
+	fmt.Fprintf(&markdown, "# %s\n\n", markdownEscaper.Replace(transcript.Question))
+
+	if transcript.Round != "" {
+		fmt.Fprintf(&markdown, "Round: %s\n\n", markdownEscaper.Replace(transcript.Round))
+	}
+
+	if transcript.Nickname != "" {
+		fmt.Fprintf(&markdown, "By **%s**\n\n", markdownEscaper.Replace(transcript.Nickname))
+	}
+
+	for _, entry := range transcript.Entries {
+		fmt.Fprintf(
+			&markdown,
+			"- **%s**: %s%s\n",
+			markdownEscaper.Replace(entry.Nickname),
+			markdownEscaper.Replace(entry.Answer),
+			formatVotes(entry.Votes),
+		)
+	}
+
+	if len(transcript.Entries) > 0 {
+		markdown.WriteString("\n")
+	}
+
+	if len(transcript.Drawing) > 0 {
+		fmt.Fprintf(
+			&markdown,
+			"![Drawing](data:image/svg+xml;base64,%s)\n",
+			base64.StdEncoding.EncodeToString(transcript.Drawing),
+		)
+	}
+
+	return []byte(markdown.String())
+}
+
+var transcriptTemplate = template.Must(template.New("transcript").Funcs(template.FuncMap{
+	"votes": formatVotes,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .Question }}</title>
+</head>
+<body>
+<h1>{{ .Question }}</h1>
+{{- if .Round }}
+<p>Round: {{ .Round }}</p>
+{{- end }}
+{{- if .Nickname }}
+<p>By <strong>{{ .Nickname }}</strong></p>
+{{- end }}
+{{- if .Entries }}
+<ul>
+{{- range .Entries }}
+<li><strong>{{ .Nickname }}</strong>: {{ .Answer }}{{ votes .Votes }}</li>
+{{- end }}
+</ul>
+{{- end }}
+{{- if .Drawing }}
+<figure>{{ .Drawing }}</figure>
+{{- end }}
+</body>
+</html>
+`))
+
+// RenderHTML renders the transcript as an HTML page, the drawing is embedded as an inline SVG.
+func (transcript Transcript) RenderHTML() ([]byte, error) {
+	data := struct {
+		Transcript
+		Drawing template.HTML
+	}{
+		Transcript: transcript,
+		// The drawing is rendered by the API from the numbers and validated colors of the drawing, so it is safe.
+		Drawing: template.HTML(transcript.Drawing), // #nosec G203
+	}
+
+	var page bytes.Buffer
+	err := transcriptTemplate.Execute(&page, data)
+	if err != nil {
+		return nil, errors.Errorf("failed to render transcript %v", err)
+	}
+
+	return page.Bytes(), nil
+}
+
+func formatVotes(votes *int) string {
+	switch {
+	case votes == nil:
+		return ""
+	case *votes == 1:
+		return " (1 vote)"
+	default:
+		return fmt.Sprintf(" (%d votes)", *votes)
+	}
+}
@@ -0,0 +1,74 @@
+package controllers_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/apikey"
+	"gitlab.com/banter-bus/banter-bus-management-api/tests/data"
+)
+
+func (s *Tests) SubTestAddAPIKey(t *testing.T) {
+	for _, tc := range data.AddAPIKey {
+		testName := fmt.Sprintf("Add API Key: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			response := s.httpExpect.POST("/apikey").
+				WithJSON(tc.Payload).
+				Expect().
+				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedStatus != http.StatusCreated {
+				return
+			}
+
+			newKey := response.JSON().Object()
+			key := newKey.Value("key").String().NotEmpty().Raw()
+			id := newKey.Value("id").String().Raw()
+
+			s.anonymous.GET("/game").
+				WithHeader(apikey.Header, key).
+				Expect().
+				Status(http.StatusOK)
+
+			s.httpExpect.GET("/apikey").
+				Expect().
+				Status(http.StatusOK).
+				Body().Contains(id).NotContains(key)
+
+			s.httpExpect.DELETE(fmt.Sprintf("/apikey/%s", id)).
+				Expect().
+				Status(http.StatusOK)
+
+			s.anonymous.GET("/game").
+				WithHeader(apikey.Header, key).
+				Expect().
+				Status(http.StatusUnauthorized)
+		})
+	}
+}
+
+func (s *Tests) SubTestRemoveAPIKeyNotFound(t *testing.T) {
+	s.httpExpect.DELETE("/apikey/doesnotexist").
+		Expect().
+		Status(http.StatusNotFound)
+}
+
+func (s *Tests) SubTestAuthorizeRequests(t *testing.T) {
+	for _, tc := range data.AuthorizeRequests {
+		testName := fmt.Sprintf("Authorize Requests: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			key := tc.Key
+			if tc.Role != "" {
+				key = s.newAPIKey(tc.Role)
+			}
+
+			request := s.anonymous.Request(tc.Method, tc.Endpoint)
+			if key != "" {
+				request = request.WithHeader(apikey.Header, key)
+			}
+
+			request.Expect().Status(tc.ExpectedStatus)
+		})
+	}
+}
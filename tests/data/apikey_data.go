@@ -0,0 +1,140 @@
+package data
+
+import (
+	"net/http"
+
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/apikey"
+)
+
+var AddAPIKey = []struct {
+	TestDescription string
+	Payload         interface{}
+	ExpectedStatus  int
+}{
+	{
+		"Add an editor API key",
+		map[string]interface{}{"name": "question-editor", "role": "editor"},
+		http.StatusCreated,
+	},
+	{
+		"Add a game server API key",
+		map[string]interface{}{"name": "quibly-server", "role": "game-server"},
+		http.StatusCreated,
+	},
+	{
+		"Try to add an API key with an invalid role",
+		map[string]interface{}{"name": "quibly-server", "role": "player"},
+		http.StatusBadRequest,
+	},
+	{
+		"Try to add an API key without a name",
+		map[string]interface{}{"role": "translator"},
+		http.StatusBadRequest,
+	},
+}
+
+var AuthorizeRequests = []struct {
+	TestDescription string
+	Role            apikey.Role
+	Key             string
+	Method          string
+	Endpoint        string
+	ExpectedStatus  int
+}{
+	{
+		"Try to get games without an API key",
+		"",
+		"",
+		http.MethodGet,
+		"/game",
+		http.StatusUnauthorized,
+	},
+	{
+		"Try to get games with an API key that does not exist",
+		"",
+		"not-a-real-key",
+		http.MethodGet,
+		"/game",
+		http.StatusUnauthorized,
+	},
+	{
+		"Get games as a translator",
+		apikey.Translator,
+		"",
+		http.MethodGet,
+		"/game",
+		http.StatusOK,
+	},
+	{
+		"Get stories as a game server",
+		apikey.GameServer,
+		"",
+		http.MethodGet,
+		"/story/quibly",
+		http.StatusOK,
+	},
+	{
+		"Try to delete a game as an editor",
+		apikey.Editor,
+		"",
+		http.MethodDelete,
+		"/game/quibly?dry_run=true",
+		http.StatusForbidden,
+	},
+	{
+		"Try to disable a game as a game server",
+		apikey.GameServer,
+		"",
+		http.MethodPut,
+		"/game/quibly/disable",
+		http.StatusForbidden,
+	},
+	{
+		"Disable a game as an editor",
+		apikey.Editor,
+		"",
+		http.MethodPut,
+		"/game/quibly/disable",
+		http.StatusOK,
+	},
+	{
+		"Try to get API keys as an editor",
+		apikey.Editor,
+		"",
+		http.MethodGet,
+		"/apikey",
+		http.StatusForbidden,
+	},
+	{
+		"Get API keys as an admin",
+		apikey.Admin,
+		"",
+		http.MethodGet,
+		"/apikey",
+		http.StatusOK,
+	},
+	{
+		"Check liveness without an API key",
+		"",
+		"",
+		http.MethodGet,
+		"/livez",
+		http.StatusOK,
+	},
+	{
+		"Get a shared story without an API key",
+		"",
+		"",
+		http.MethodGet,
+		"/share/not-a-real-token",
+		http.StatusNotFound,
+	},
+	{
+		"Get the OpenAPI specification without an API key",
+		"",
+		"",
+		http.MethodGet,
+		"/openapi",
+		http.StatusOK,
+	},
+}
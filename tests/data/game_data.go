@@ -2,8 +2,10 @@ package data
 
 import (
 	"net/http"
+	"time"
 
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/games"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/questions"
 )
 
 var AddGame = []struct {
@@ -92,6 +94,64 @@ var GetAllGames = []struct {
 	},
 }
 
+var GetAvailableGames = []struct {
+	TestDescription string
+	Language        string
+	Region          string
+	ExpectedStatus  int
+	ExpectedNames   []string
+}{
+	{
+		"Get games available in a language",
+		"fr",
+		"",
+		http.StatusOK,
+		[]string{
+			"quibly",
+			"fibbing_it",
+		},
+	},
+	{
+		"Get games available in a language, including games restricted to that language",
+		"en",
+		"",
+		http.StatusOK,
+		[]string{
+			"quibly",
+			"fibbing_it",
+			"drawlosseum",
+		},
+	},
+	{
+		"Get games available in a region",
+		"",
+		"US",
+		http.StatusOK,
+		[]string{
+			"quibly",
+			"fibbing_it",
+		},
+	},
+	{
+		"Get games available in a language and region",
+		"en",
+		"GB",
+		http.StatusOK,
+		[]string{
+			"quibly",
+			"fibbing_it",
+			"drawlosseum",
+		},
+	},
+	{
+		"Try to get games with an invalid region",
+		"",
+		"not a region",
+		http.StatusBadRequest,
+		[]string{},
+	},
+}
+
 var GetGame = []struct {
 	TestDescription string
 	Name            string
@@ -133,24 +193,257 @@ var GetGame = []struct {
 }
 
 var RemoveGame = []struct {
-	TestDescription string
-	Name            string
-	ExpectedStatus  int
+	TestDescription   string
+	Name              string
+	DryRun            bool
+	ConfirmationToken string
+	Preview           bool
+	StoredToken       *games.RemovalToken
+	SharedStoryID     string
+	ExpectedStatus    int
+	ExpectedResult    games.GameRemovalOut
 }{
+	{
+		"Preview removing an existing game",
+		"quibly",
+		true,
+		"",
+		false,
+		nil,
+		"",
+		http.StatusOK,
+		games.GameRemovalOut{
+			DryRun:       true,
+			Questions:    4,
+			Translations: 9,
+			Stories:      1,
+		},
+	},
+	{
+		"Try to remove a game without a confirmation token",
+		"quibly",
+		false,
+		"",
+		false,
+		nil,
+		"",
+		http.StatusBadRequest,
+		games.GameRemovalOut{},
+	},
+	{
+		"Try to remove a game with a confirmation token that wasn't from a preview",
+		"quibly",
+		false,
+		"Yd3m8Qx1pLk7vT2nW9cJ4fR6hB0sZ5gE1uN7aK3oXwI",
+		false,
+		nil,
+		"",
+		http.StatusBadRequest,
+		games.GameRemovalOut{},
+	},
+	{
+		"Try to remove a game with a confirmation token from another game",
+		"quibly",
+		false,
+		"",
+		false,
+		&games.RemovalToken{
+			Token:     "fibbing-it-removal-token",
+			GameName:  "fibbing_it",
+			Removal:   games.Removal{Questions: 4, Translations: 9, Stories: 1},
+			ExpiresAt: time.Now().Add(time.Hour),
+		},
+		"",
+		http.StatusBadRequest,
+		games.GameRemovalOut{},
+	},
+	{
+		"Try to remove a game with an out of date confirmation token",
+		"quibly",
+		false,
+		"",
+		false,
+		&games.RemovalToken{
+			Token:     "out-of-date-removal-token",
+			GameName:  "quibly",
+			Removal:   games.Removal{Questions: 3, Translations: 7, Stories: 1},
+			ExpiresAt: time.Now().Add(time.Hour),
+		},
+		"",
+		http.StatusBadRequest,
+		games.GameRemovalOut{},
+	},
+	{
+		"Try to remove a game with an expired confirmation token",
+		"quibly",
+		false,
+		"",
+		false,
+		&games.RemovalToken{
+			Token:     "expired-removal-token",
+			GameName:  "quibly",
+			Removal:   games.Removal{Questions: 4, Translations: 9, Stories: 1},
+			ExpiresAt: time.Now().Add(-time.Minute),
+		},
+		"",
+		http.StatusBadRequest,
+		games.GameRemovalOut{},
+	},
 	{
 		"Remove an existing game",
 		"quibly",
+		false,
+		"",
+		true,
+		nil,
+		"1def4233-f674-4a3f-863d-6e850bfbfdb4",
 		http.StatusOK,
+		games.GameRemovalOut{
+			Questions:    4,
+			Translations: 9,
+			Stories:      1,
+		},
+	},
+	{
+		"Try to preview removing a game that's already been removed",
+		"quibly",
+		true,
+		"",
+		false,
+		nil,
+		"",
+		http.StatusNotFound,
+		games.GameRemovalOut{},
 	},
 	{
 		"Try to remove a game that's already been removed",
 		"quibly",
+		false,
+		"Yd3m8Qx1pLk7vT2nW9cJ4fR6hB0sZ5gE1uN7aK3oXwI",
+		false,
+		nil,
+		"",
 		http.StatusNotFound,
+		games.GameRemovalOut{},
 	},
 	{
 		"Try to remove another game that doesn't exist",
 		"quiblyv3",
+		false,
+		"",
+		false,
+		nil,
+		"",
+		http.StatusNotFound,
+		games.GameRemovalOut{},
+	},
+}
+
+var UpdateGameLanguage = []struct {
+	TestDescription   string
+	Name              string
+	Language          string
+	Enable            bool
+	ExpectedStatus    int
+	ExpectedLanguages []string
+}{
+	{
+		"Disable a game in a language",
+		"fibbing_it",
+		"it",
+		false,
+		http.StatusOK,
+		[]string{"en"},
+	},
+	{
+		"Enable a game in another language",
+		"fibbing_it",
+		"fr",
+		true,
+		http.StatusOK,
+		[]string{"en", "fr"},
+	},
+	{
+		"Enable a game in a language it is already available in",
+		"drawlosseum",
+		"en",
+		true,
+		http.StatusOK,
+		[]string{"en"},
+	},
+	{
+		"Try to disable a game in its only language",
+		"drawlosseum",
+		"en",
+		false,
+		http.StatusBadRequest,
+		[]string{},
+	},
+	{
+		"Try to enable a game in an invalid language",
+		"quibly",
+		"not a language",
+		true,
+		http.StatusBadRequest,
+		[]string{},
+	},
+	{
+		"Try to disable a game that doesn't exist in a language",
+		"quiblyv3",
+		"en",
+		false,
+		http.StatusNotFound,
+		[]string{},
+	},
+}
+
+var GetGameStats = []struct {
+	TestDescription string
+	Name            string
+	ExpectedStatus  int
+	ExpectedResult  games.GameStatsOut
+}{
+	{
+		"Get stats of a game without groups",
+		"quibly",
+		http.StatusOK,
+		games.GameStatsOut{
+			Questions: games.QuestionStatsOut{
+				Total:      4,
+				Enabled:    3,
+				Disabled:   1,
+				ByRound:    map[string]int{"pair": 2, "answers": 1, "group": 1},
+				ByGroup:    map[string]int{},
+				ByLanguage: map[string]int{"en": 3, "de": 3, "ur": 2, "fr": 1},
+				Stories:    map[string]int{},
+			},
+			Stories:             1,
+			TranslationCoverage: map[string]float64{"en": 75, "de": 75, "ur": 50, "fr": 25},
+		},
+	},
+	{
+		"Get stats of a game with groups",
+		"fibbing_it",
+		http.StatusOK,
+		games.GameStatsOut{
+			Questions: games.QuestionStatsOut{
+				Total:      10,
+				Enabled:    7,
+				Disabled:   3,
+				ByRound:    map[string]int{"opinion": 5, "free_form": 3, "likely": 2},
+				ByGroup:    map[string]int{"horse_group": 5, "bike_group": 2, "cat_group": 1},
+				ByLanguage: map[string]int{"en": 9, "it": 1},
+				Stories:    map[string]int{},
+			},
+			Stories:             3,
+			TranslationCoverage: map[string]float64{"en": 90, "it": 10},
+		},
+	},
+	{
+		"Try to get stats of a game that doesn't exist",
+		"quiblyv3",
 		http.StatusNotFound,
+		games.GameStatsOut{},
 	},
 }
 
@@ -168,6 +461,10 @@ var EnableGame = []struct {
 			Name:     "drawlosseum",
 			RulesURL: "https://google.com/drawlosseum",
 			Enabled:  true,
+			GameMetadataOut: games.GameMetadataOut{
+				Languages: []string{"en"},
+				Regions:   []string{"GB"},
+			},
 		},
 	},
 	{
@@ -178,6 +475,10 @@ var EnableGame = []struct {
 			Name:     "drawlosseum",
 			RulesURL: "https://google.com/drawlosseum",
 			Enabled:  true,
+			GameMetadataOut: games.GameMetadataOut{
+				Languages: []string{"en"},
+				Regions:   []string{"GB"},
+			},
 		},
 	},
 	{
@@ -221,3 +522,350 @@ var DisableGame = []struct {
 		games.GameOut{},
 	},
 }
+
+var GetGameRules = []struct {
+	TestDescription string
+	Name            string
+	ExpectedStatus  int
+	ExpectedRules   games.GameRulesInOut
+}{
+	{
+		"Get the default rules of a game",
+		"fibbing_it",
+		http.StatusOK,
+		games.GameRulesInOut{
+			Rounds: []games.RoundRulesInOut{
+				{Name: "opinion", HasGroups: true, GroupTypes: []string{"answer", "question"}},
+				{Name: "likely"},
+				{Name: "free_form", HasGroups: true, GroupTypes: []string{"answer", "question"}},
+			},
+		},
+	},
+	{
+		"Get the default rules of another game",
+		"quibly",
+		http.StatusOK,
+		games.GameRulesInOut{
+			Rounds: []games.RoundRulesInOut{
+				{Name: "pair"},
+				{Name: "group"},
+				{Name: "answers"},
+			},
+		},
+	},
+	{
+		"Try to get the rules of a game that doesn't exist",
+		"quiblyv3",
+		http.StatusNotFound,
+		games.GameRulesInOut{},
+	},
+}
+
+var UpdateGameRules = []struct {
+	TestDescription string
+	Name            string
+	Payload         interface{}
+	ExpectedStatus  int
+	Question        questions.QuestionIn
+	QuestionStatus  int
+}{
+	{
+		"Add a new round to a game",
+		"quibly",
+		&games.GameRulesInOut{
+			Rounds: []games.RoundRulesInOut{
+				{Name: "pair"},
+				{Name: "group"},
+				{Name: "answers"},
+				{Name: "quick_fire", MaxContentLength: 20},
+			},
+		},
+		http.StatusOK,
+		questions.QuestionIn{
+			Content: "fastest animal?",
+			Round:   "quick_fire",
+		},
+		http.StatusCreated,
+	},
+	{
+		"Question is longer than the round allows",
+		"quibly",
+		&games.GameRulesInOut{
+			Rounds: []games.RoundRulesInOut{
+				{Name: "quick_fire", MaxContentLength: 10},
+			},
+		},
+		http.StatusOK,
+		questions.QuestionIn{
+			Content: "what is the fastest animal?",
+			Round:   "quick_fire",
+		},
+		http.StatusBadRequest,
+	},
+	{
+		"Remove a round from a game",
+		"quibly",
+		&games.GameRulesInOut{
+			Rounds: []games.RoundRulesInOut{
+				{Name: "pair"},
+			},
+		},
+		http.StatusOK,
+		questions.QuestionIn{
+			Content: "who is the funniest?",
+			Round:   "group",
+		},
+		http.StatusBadRequest,
+	},
+	{
+		"Add a group type that doesn't exist",
+		"fibbing_it",
+		&games.GameRulesInOut{
+			Rounds: []games.RoundRulesInOut{
+				{Name: "opinion", HasGroups: true, GroupTypes: []string{"answers"}},
+			},
+		},
+		http.StatusBadRequest,
+		questions.QuestionIn{},
+		0,
+	},
+	{
+		"Add the same round twice",
+		"fibbing_it",
+		&games.GameRulesInOut{
+			Rounds: []games.RoundRulesInOut{
+				{Name: "likely"},
+				{Name: "likely"},
+			},
+		},
+		http.StatusBadRequest,
+		questions.QuestionIn{},
+		0,
+	},
+	{
+		"Try to update the rules of a game that doesn't exist",
+		"quiblyv3",
+		&games.GameRulesInOut{
+			Rounds: []games.RoundRulesInOut{
+				{Name: "pair"},
+			},
+		},
+		http.StatusNotFound,
+		questions.QuestionIn{},
+		0,
+	},
+}
+
+var (
+	minPlayers      = 3
+	maxPlayers      = 8
+	tooFewPlayers   = 10
+	durationMinutes = 20
+	icon            = "icons/quibly.svg"
+	tags            = []string{"party", "writing"}
+	displayNames    = map[string]string{"en": "Quibly", "fr": "Quibly"}
+	invalidNames    = map[string]string{"ennnn": "Quibly"}
+)
+
+var UpdateGameMetadata = []struct {
+	TestDescription string
+	Name            string
+	Payload         interface{}
+	ExpectedStatus  int
+	ExpectedGame    games.GameOut
+}{
+	{
+		"Update all the metadata of a game",
+		"quibly",
+		&games.GameMetadataIn{
+			MinPlayers:      &minPlayers,
+			MaxPlayers:      &maxPlayers,
+			DurationMinutes: &durationMinutes,
+			DisplayNames:    &displayNames,
+			Tags:            &tags,
+			Icon:            &icon,
+		},
+		http.StatusOK,
+		games.GameOut{
+			Name:     "quibly",
+			RulesURL: "https://gitlab.com/banter-bus/banter-bus-server/-/wikis/docs/rules/quibly",
+			Enabled:  true,
+			GameMetadataOut: games.GameMetadataOut{
+				MinPlayers:      3,
+				MaxPlayers:      8,
+				DurationMinutes: 20,
+				DisplayNames:    map[string]string{"en": "Quibly", "fr": "Quibly"},
+				Tags:            []string{"party", "writing"},
+				Icon:            "icons/quibly.svg",
+			},
+		},
+	},
+	{
+		"Update some of the metadata of a game",
+		"drawlosseum",
+		&games.GameMetadataIn{
+			Icon: &icon,
+		},
+		http.StatusOK,
+		games.GameOut{
+			Name:     "drawlosseum",
+			RulesURL: "https://google.com/drawlosseum",
+			Enabled:  false,
+			GameMetadataOut: games.GameMetadataOut{
+				Icon:      "icons/quibly.svg",
+				Languages: []string{"en"},
+				Regions:   []string{"GB"},
+			},
+		},
+	},
+	{
+		"Min players larger than max players",
+		"quibly",
+		&games.GameMetadataIn{
+			MinPlayers: &tooFewPlayers,
+			MaxPlayers: &maxPlayers,
+		},
+		http.StatusBadRequest,
+		games.GameOut{},
+	},
+	{
+		"Invalid language code in display names",
+		"quibly",
+		&games.GameMetadataIn{
+			DisplayNames: &invalidNames,
+		},
+		http.StatusBadRequest,
+		games.GameOut{},
+	},
+	{
+		"Try to update a game that doesn't exist",
+		"quiblyv3",
+		&games.GameMetadataIn{
+			Icon: &icon,
+		},
+		http.StatusNotFound,
+		games.GameOut{},
+	},
+}
+
+var GetRounds = []struct {
+	TestDescription string
+	Name            string
+	ExpectedStatus  int
+	ExpectedRounds  []games.RoundOut
+}{
+	{
+		"Get the rounds of a game without groups",
+		"quibly",
+		http.StatusOK,
+		[]games.RoundOut{
+			{
+				RoundRulesInOut: games.RoundRulesInOut{Name: "pair"},
+				Examples: []questions.QuestionIn{
+					{Content: "This is a funny question?", LanguageCode: "en", Round: "pair"},
+				},
+			},
+			{
+				RoundRulesInOut: games.RoundRulesInOut{Name: "group"},
+				Examples: []questions.QuestionIn{
+					{Content: "This is a funny question?", LanguageCode: "en", Round: "group"},
+				},
+			},
+			{
+				RoundRulesInOut: games.RoundRulesInOut{Name: "answers"},
+				Examples: []questions.QuestionIn{
+					{Content: "This is a funny question?", LanguageCode: "en", Round: "answers"},
+				},
+			},
+		},
+	},
+	{
+		"Get the rounds of a game with groups",
+		"fibbing_it",
+		http.StatusOK,
+		[]games.RoundOut{
+			{
+				RoundRulesInOut: games.RoundRulesInOut{
+					Name:       "opinion",
+					HasGroups:  true,
+					GroupTypes: []string{"answer", "question"},
+				},
+				Examples: []questions.QuestionIn{
+					{Content: "This is a funny question?", LanguageCode: "en", Round: "opinion"},
+					{
+						Content:      "This is a funny question?",
+						LanguageCode: "en",
+						Round:        "opinion",
+						Group:        &questions.QuestionGroupInOut{Name: "example_group", Type: "answer"},
+					},
+					{
+						Content:      "This is a funny question?",
+						LanguageCode: "en",
+						Round:        "opinion",
+						Group:        &questions.QuestionGroupInOut{Name: "example_group", Type: "question"},
+					},
+				},
+			},
+			{
+				RoundRulesInOut: games.RoundRulesInOut{Name: "likely"},
+				Examples: []questions.QuestionIn{
+					{Content: "This is a funny question?", LanguageCode: "en", Round: "likely"},
+				},
+			},
+			{
+				RoundRulesInOut: games.RoundRulesInOut{
+					Name:       "free_form",
+					HasGroups:  true,
+					GroupTypes: []string{"answer", "question"},
+				},
+				Examples: []questions.QuestionIn{
+					{Content: "This is a funny question?", LanguageCode: "en", Round: "free_form"},
+					{
+						Content:      "This is a funny question?",
+						LanguageCode: "en",
+						Round:        "free_form",
+						Group:        &questions.QuestionGroupInOut{Name: "example_group", Type: "answer"},
+					},
+					{
+						Content:      "This is a funny question?",
+						LanguageCode: "en",
+						Round:        "free_form",
+						Group:        &questions.QuestionGroupInOut{Name: "example_group", Type: "question"},
+					},
+				},
+			},
+		},
+	},
+	{
+		"Try to get the rounds of a game that doesn't exist",
+		"quiblyv3",
+		http.StatusNotFound,
+		[]games.RoundOut{},
+	},
+}
+
+var PurgeExpiredStories = []struct {
+	TestDescription string
+	RetentionDays   map[string]int
+	DaysFromNow     int
+	ExpectedPurged  map[string]int
+}{
+	{
+		"Purge stories before they have expired",
+		map[string]int{"quibly": 2, "fibbing_it": 7},
+		1,
+		map[string]int{"quibly": 0, "fibbing_it": 0},
+	},
+	{
+		"Purge expired stories of a game",
+		map[string]int{"quibly": 2, "fibbing_it": 7},
+		3,
+		map[string]int{"quibly": 1, "fibbing_it": 0},
+	},
+	{
+		"Purge expired stories of every game with a retention period",
+		map[string]int{"quibly": 2, "fibbing_it": 7},
+		8,
+		map[string]int{"quibly": 0, "fibbing_it": 3},
+	},
+}
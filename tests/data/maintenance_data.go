@@ -0,0 +1,23 @@
+package data
+
+import "net/http"
+
+var GetReadiness = []struct {
+	TestDescription  string
+	RemoveCollection string
+	ExpectedStatus   int
+	ExpectedChecks   map[string]bool
+}{
+	{
+		"API is ready",
+		"",
+		http.StatusOK,
+		map[string]bool{"database": true, "indexes": true, "shutdown": true},
+	},
+	{
+		"API is not ready when an index is missing",
+		"share",
+		http.StatusServiceUnavailable,
+		map[string]bool{"database": true, "indexes": false, "shutdown": true},
+	},
+}
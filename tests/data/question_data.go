@@ -60,6 +60,22 @@ var AddQuestion = []struct {
 			Content: "food fight",
 		}, http.StatusCreated,
 	},
+	{
+		"Add a question to drawlosseum, round drawing",
+		"drawlosseum",
+		&questions.QuestionIn{
+			Content: "bicycle",
+			Round:   "drawing",
+		}, http.StatusCreated,
+	},
+	{
+		"Try to add a question to drawlosseum with a round the game doesn't have, which was allowed before rounds were stored in the game",
+		"drawlosseum",
+		&questions.QuestionIn{
+			Content: "teapot",
+			Round:   "opinion",
+		}, http.StatusBadRequest,
+	},
 	{
 		"Add a question to fibbing it, round opinion new group bike group, language en",
 		"fibbing_it",
@@ -214,6 +230,62 @@ var AddQuestion = []struct {
 			},
 		}, http.StatusBadRequest,
 	},
+	{
+		"Add a question to quibly with a placeholder",
+		"quibly",
+		&questions.QuestionIn{
+			Content: "What would {player} do with a million pounds?",
+			Round:   "pair",
+		}, http.StatusCreated,
+	},
+	{
+		"Add a question to quibly with a plural placeholder",
+		"quibly",
+		&questions.QuestionIn{
+			Content: "What would you do with {count, plural, one {# pound} other {# pounds}}?",
+			Round:   "pair",
+		}, http.StatusCreated,
+	},
+	{
+		"Add a question to quibly with a plural placeholder with an offset",
+		"quibly",
+		&questions.QuestionIn{
+			Content: "Who would you and {count, plural, offset:1 =0 {nobody} one {# other person} other {# other people}} invite?",
+			Round:   "pair",
+		}, http.StatusCreated,
+	},
+	{
+		"Add a question to quibly with a plural placeholder with an invalid offset",
+		"quibly",
+		&questions.QuestionIn{
+			Content: "Who would you and {count, plural, offset:one one {# other person} other {# other people}} invite?",
+			Round:   "pair",
+		}, http.StatusBadRequest,
+	},
+	{
+		"Add a question to quibly with a plural placeholder with an offset after an option",
+		"quibly",
+		&questions.QuestionIn{
+			Content: "Who would you and {count, plural, one {# other person} offset:1 other {# other people}} invite?",
+			Round:   "pair",
+		}, http.StatusBadRequest,
+	},
+	{
+		"Add a question to quibly with an unclosed placeholder",
+		"quibly",
+		&questions.QuestionIn{
+			Content: "What would {player do with a million pounds?",
+			Round:   "pair",
+		}, http.StatusBadRequest,
+	},
+	{
+		"Add a question to quibly with a plural placeholder missing the other option",
+		"quibly",
+		&questions.QuestionIn{
+			Content: "What would you do with {count, plural, one {# pound}}?",
+			Round:   "pair",
+		}, http.StatusBadRequest,
+	},
 	{
 		"game does not exist but missing content",
 		"quibly v3",
@@ -492,6 +564,15 @@ var AddTranslationQuestion = []struct {
 		"a9c00e19-d41e-4b15-a8bd-ec921af9123d",
 		&questions.QuestionIn{}, http.StatusBadRequest,
 	},
+	{
+		"Translation uses different placeholders to the other languages",
+		"quibly",
+		"fr",
+		"4d18ac45-8034-4f8e-b636-cf730b17e51a",
+		&questions.QuestionTranslationIn{
+			Content: "what would {player} do?",
+		}, http.StatusBadRequest,
+	},
 	{
 		"Update question in fibbing it but invalid language code",
 		"fibbing_it",
@@ -1240,6 +1321,187 @@ var GetQuestionById = []struct {
 	},
 }
 
+var GetFullQuestion = []struct {
+	TestDescription string
+	Game            string
+	ID              string
+	ExpectedPayload questions.QuestionFullOut
+	Expected        int
+}{
+	{
+		"Get a quibly question with all translations",
+		"quibly",
+		"4d18ac45-8034-4f8e-b636-cf730b17e51a",
+		questions.QuestionFullOut{
+			ID:      "4d18ac45-8034-4f8e-b636-cf730b17e51a",
+			Round:   "pair",
+			Enabled: true,
+			Content: map[string]string{
+				"en": "this is a question?",
+				"ur": "this is a question?",
+				"de": "this is a question?",
+			},
+		},
+		http.StatusOK,
+	},
+	{
+		"Get a fibbing it question with all translations",
+		"fibbing_it",
+		"d80f2d90-0fb0-462a-8fbd-1aa00b4e42a5",
+		questions.QuestionFullOut{
+			ID:      "d80f2d90-0fb0-462a-8fbd-1aa00b4e42a5",
+			Round:   "free_form",
+			Enabled: false,
+			Content: map[string]string{
+				"it": "Perché sono superiori i gatti di Liam?",
+			},
+			Group: &questions.QuestionGroupInOut{
+				Name: "cat_group",
+			},
+		},
+		http.StatusOK,
+	},
+	{
+		"Game does not exist",
+		"quibly v3",
+		"4d18ac45-8034-4f8e-b636-cf730b17e51a",
+		questions.QuestionFullOut{}, http.StatusNotFound,
+	},
+	{
+		"Question does not exist",
+		"fibbing_it",
+		"9f64d60c-62ee-420a-976e-bfcaec77ad8b",
+		questions.QuestionFullOut{}, http.StatusNotFound,
+	},
+}
+
+var RenderQuestion = []struct {
+	TestDescription string
+	Game            string
+	LanguageCode    string
+	ID              string
+	Payload         questions.QuestionRenderIn
+	ExpectedPayload questions.QuestionRenderOut
+	Expected        int
+}{
+	{
+		"Render a question without placeholders",
+		"quibly",
+		"en",
+		"4d18ac45-8034-4f8e-b636-cf730b17e51a",
+		questions.QuestionRenderIn{},
+		questions.QuestionRenderOut{
+			Content: "this is a question?",
+		},
+		http.StatusOK,
+	},
+	{
+		"Render a question with unused values",
+		"drawlosseum",
+		"en",
+		"101464a5-337f-4ce7-a4df-2b00764e5d8d",
+		questions.QuestionRenderIn{
+			Values: map[string]string{"player": "Liam"},
+		},
+		questions.QuestionRenderOut{
+			Content: "spoon",
+		},
+		http.StatusOK,
+	},
+	{
+		"Render a question in a language it has not been translated to",
+		"drawlosseum",
+		"de",
+		"101464a5-337f-4ce7-a4df-2b00764e5d8d",
+		questions.QuestionRenderIn{},
+		questions.QuestionRenderOut{},
+		http.StatusNotFound,
+	},
+	{
+		"Render a question with an invalid language code",
+		"drawlosseum",
+		"deeee",
+		"101464a5-337f-4ce7-a4df-2b00764e5d8d",
+		questions.QuestionRenderIn{},
+		questions.QuestionRenderOut{},
+		http.StatusBadRequest,
+	},
+	{
+		"Question does not exist",
+		"fibbing_it",
+		"en",
+		"9f64d60c-62ee-420a-976e-bfcaec77ad8b",
+		questions.QuestionRenderIn{},
+		questions.QuestionRenderOut{},
+		http.StatusNotFound,
+	},
+}
+
+var RenderAddedQuestion = []struct {
+	TestDescription string
+	Game            string
+	Question        questions.QuestionIn
+	Payload         questions.QuestionRenderIn
+	ExpectedPayload questions.QuestionRenderOut
+}{
+	{
+		"Render a plural placeholder",
+		"quibly",
+		questions.QuestionIn{
+			Content: "What would you do with {count, plural, one {# pound} other {# pounds}}?",
+			Round:   "pair",
+		},
+		questions.QuestionRenderIn{
+			Values: map[string]string{"count": "1"},
+		},
+		questions.QuestionRenderOut{
+			Content: "What would you do with 1 pound?",
+		},
+	},
+	{
+		"Render a plural placeholder with an offset",
+		"quibly",
+		questions.QuestionIn{
+			Content: "Who would you and {count, plural, offset:1 =0 {nobody} one {# other person} other {# other people}} invite to dinner?",
+			Round:   "pair",
+		},
+		questions.QuestionRenderIn{
+			Values: map[string]string{"count": "2"},
+		},
+		questions.QuestionRenderOut{
+			Content: "Who would you and 1 other person invite to dinner?",
+		},
+	},
+	{
+		"Render a plural placeholder with an offset using more than one of the form",
+		"quibly",
+		questions.QuestionIn{
+			Content: "Who would you and {count, plural, offset:1 =0 {nobody} one {# other person} other {# other people}} invite to lunch?",
+			Round:   "pair",
+		},
+		questions.QuestionRenderIn{
+			Values: map[string]string{"count": "4"},
+		},
+		questions.QuestionRenderOut{
+			Content: "Who would you and 3 other people invite to lunch?",
+		},
+	},
+	{
+		"Render a plural placeholder with an offset using an exact match",
+		"quibly",
+		questions.QuestionIn{
+			Content: "Who would you and {count, plural, offset:1 =0 {nobody} one {# other person} other {# other people}} invite to the party?",
+			Round:   "pair",
+		},
+		questions.QuestionRenderIn{
+			Values: map[string]string{"count": "0"},
+		},
+		questions.QuestionRenderOut{
+			Content: "Who would you and nobody invite to the party?",
+		},
+	},
+}
+
 var GetAllQuestionsIds = []struct {
 	TestDescription string
 	Game            string
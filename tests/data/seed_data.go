@@ -0,0 +1,205 @@
+package data
+
+import (
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/games"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/seed"
+)
+
+var ApplySeed = []struct {
+	TestDescription string
+	Seed            seed.Seed
+	Update          bool
+	ExpectError     bool
+	ExpectedReport  seed.Report
+}{
+	{
+		"Apply seed with an existing game and a new question",
+		seed.Seed{
+			Games: []seed.GameSeed{
+				{
+					Name:     "quibly",
+					RulesURL: "https://gitlab.com/banter-bus/banter-bus-server/-/wikis/docs/rules/quibly",
+					Questions: []seed.QuestionSeed{
+						{
+							Round:   "pair",
+							Content: map[string]string{"en": "What is the worst thing to say on a first date?"},
+						},
+					},
+				},
+			},
+		},
+		false,
+		false,
+		seed.Report{
+			Games:     seed.Counts{Skipped: 1},
+			Questions: seed.Counts{Created: 1},
+		},
+	},
+	{
+		"Apply seed with a question that already exists",
+		seed.Seed{
+			Games: []seed.GameSeed{
+				{
+					Name:     "quibly",
+					RulesURL: "https://gitlab.com/banter-bus/banter-bus-server/-/wikis/docs/rules/quibly",
+					Questions: []seed.QuestionSeed{
+						{
+							Round:   "pair",
+							Content: map[string]string{"en": "this is a question?"},
+						},
+					},
+				},
+			},
+		},
+		false,
+		false,
+		seed.Report{
+			Games:     seed.Counts{Skipped: 1},
+			Questions: seed.Counts{Skipped: 1},
+		},
+	},
+	{
+		"Apply seed updating an existing game and question",
+		seed.Seed{
+			Games: []seed.GameSeed{
+				{
+					Name:       "fibbing_it",
+					RulesURL:   "https://gitlab.com/banter-bus/banter-bus-server/-/wikis/docs/rules/fibbing_it",
+					MinPlayers: 3,
+					MaxPlayers: 8,
+					Questions: []seed.QuestionSeed{
+						{
+							ID:      "3f8c3d4a-6a3c-4a54-8a3c-3d4a6a3c4a54",
+							Round:   "likely",
+							Content: map[string]string{"en": "to never eat a vegetable again?"},
+						},
+					},
+				},
+			},
+		},
+		true,
+		false,
+		seed.Report{
+			Games:     seed.Counts{Updated: 1},
+			Questions: seed.Counts{Created: 1},
+		},
+	},
+	{
+		"Apply seed matching a question by its content in the default language",
+		seed.Seed{
+			Games: []seed.GameSeed{
+				{
+					Name:     "quibly",
+					RulesURL: "https://gitlab.com/banter-bus/banter-bus-server/-/wikis/docs/rules/quibly",
+					Questions: []seed.QuestionSeed{
+						{
+							Round:   "pair",
+							Content: map[string]string{"de": "ist das eine Frage?", "en": "this is a question?"},
+						},
+					},
+				},
+			},
+		},
+		false,
+		false,
+		seed.Report{
+			Games:     seed.Counts{Skipped: 1},
+			Questions: seed.Counts{Skipped: 1},
+		},
+	},
+	{
+		"Apply seed with a question without an id or content in the default language",
+		seed.Seed{
+			Games: []seed.GameSeed{
+				{
+					Name:     "quibly",
+					RulesURL: "https://gitlab.com/banter-bus/banter-bus-server/-/wikis/docs/rules/quibly",
+					Questions: []seed.QuestionSeed{
+						{
+							Round:   "pair",
+							Content: map[string]string{"fr": "est-ce une question ?"},
+						},
+					},
+				},
+			},
+		},
+		false,
+		true,
+		seed.Report{},
+	},
+	{
+		"Apply seed with a game that is not registered",
+		seed.Seed{
+			Games: []seed.GameSeed{
+				{
+					Name:     "quiblyv3",
+					RulesURL: "https://gitlab.com/banter-bus/banter-bus-server/-/wikis/docs/rules/quibly",
+				},
+			},
+		},
+		false,
+		true,
+		seed.Report{},
+	},
+	{
+		"Apply seed with a question for an invalid round",
+		seed.Seed{
+			Games: []seed.GameSeed{
+				{
+					Name:     "quibly",
+					RulesURL: "https://gitlab.com/banter-bus/banter-bus-server/-/wikis/docs/rules/quibly",
+					Questions: []seed.QuestionSeed{
+						{
+							Round:   "invalid",
+							Content: map[string]string{"en": "this is a question?"},
+						},
+					},
+				},
+			},
+		},
+		false,
+		true,
+		seed.Report{},
+	},
+}
+
+var enabled = false
+
+var ApplySeedUpdate = []struct {
+	TestDescription string
+	Seed            seed.Seed
+	ExpectedGame    games.Game
+	QuestionID      string
+	ExpectedContent map[string]string
+}{
+	{
+		"Update a game and question without changing the fields missing from the seed",
+		seed.Seed{
+			Games: []seed.GameSeed{
+				{
+					Name:         "drawlosseum",
+					MinPlayers:   2,
+					DisplayNames: map[string]string{"fr": "Drawlosseum"},
+					Questions: []seed.QuestionSeed{
+						{
+							ID:      "815464a5-337f-4ce7-a4df-2b00764e5c6c",
+							Round:   "drawing",
+							Content: map[string]string{"fr": "cheval"},
+						},
+					},
+				},
+			},
+		},
+		games.Game{
+			Name:         "drawlosseum",
+			RulesURL:     "https://google.com/drawlosseum",
+			Enabled:      &enabled,
+			MinPlayers:   2,
+			DisplayNames: map[string]string{"fr": "Drawlosseum"},
+			Languages:    []string{"en"},
+			Regions:      []string{"GB"},
+		},
+		"815464a5-337f-4ce7-a4df-2b00764e5c6c",
+		map[string]string{"en": "horse", "fr": "cheval"},
+	},
+}
@@ -2,6 +2,7 @@ package data
 
 import (
 	"net/http"
+	"time"
 
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/story"
 )
@@ -11,6 +12,7 @@ var AddStories = []struct {
 	GameName        string
 	Payload         story.StoryInOut
 	ExpectedStatus  int
+	ExpectedMessage string
 }{
 	{
 		"Add a story: Quibly",
@@ -34,6 +36,7 @@ var AddStories = []struct {
 			},
 		},
 		http.StatusCreated,
+		"",
 	},
 	{
 		"Add a story: Drawlosseum",
@@ -58,8 +61,73 @@ var AddStories = []struct {
 			},
 		},
 		http.StatusCreated,
+		"",
+	},
+	{
+		"Add a story with a named color: Drawlosseum",
+		"drawlosseum",
+		story.StoryInOut{
+			Question: "fish",
+			Nickname: "i_cannotDraw",
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				Drawlosseum: story.DrawlosseumAnswersInOut{
+					{Start: story.DrawingPoint{X: 0, Y: 0}, End: story.DrawingPoint{X: 960, Y: -540}, Color: "RebeccaPurple"},
+					{Start: story.DrawingPoint{X: 10, Y: 10}, End: story.DrawingPoint{X: 20, Y: 20}, Color: "#ff00aa"},
+				},
+			},
+		},
+		http.StatusCreated,
+		"",
+	},
+	{
+		"Try to add a drawing outside of the canvas: Drawlosseum",
+		"drawlosseum",
+		story.StoryInOut{
+			Question: "fish",
+			Nickname: "i_cannotDraw",
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				Drawlosseum: story.DrawlosseumAnswersInOut{
+					{Start: story.DrawingPoint{X: 0, Y: 0}, End: story.DrawingPoint{X: 10, Y: 10}, Color: "#000"},
+					{Start: story.DrawingPoint{X: 0, Y: 0}, End: story.DrawingPoint{X: 5000, Y: 10}, Color: "#000"},
+				},
+			},
+		},
+		http.StatusBadRequest,
+		"invalid segments at indexes 1 (end is outside the canvas).",
+	},
+	{
+		"Try to add a drawing just outside the edges of the canvas: Drawlosseum",
+		"drawlosseum",
+		story.StoryInOut{
+			Question: "fish",
+			Nickname: "i_cannotDraw",
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				Drawlosseum: story.DrawlosseumAnswersInOut{
+					{Start: story.DrawingPoint{X: -961, Y: 0}, End: story.DrawingPoint{X: 0, Y: 541}, Color: "#000"},
+					{Start: story.DrawingPoint{X: -960, Y: 540}, End: story.DrawingPoint{X: 960, Y: -540}, Color: "#000"},
+					{Start: story.DrawingPoint{X: 0, Y: 0}, End: story.DrawingPoint{X: 0, Y: -540.5}, Color: "#000"},
+				},
+			},
+		},
+		http.StatusBadRequest,
+		"invalid segments at indexes 0 (start is outside the canvas, end is outside the canvas), 2 (end is outside the canvas).",
+	},
+	{
+		"Try to add a drawing with an invalid color: Drawlosseum",
+		"drawlosseum",
+		story.StoryInOut{
+			Question: "fish",
+			Nickname: "i_cannotDraw",
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				Drawlosseum: story.DrawlosseumAnswersInOut{
+					{Start: story.DrawingPoint{X: 0, Y: 0}, End: story.DrawingPoint{X: 10, Y: 10}, Color: "blurple"},
+					{Start: story.DrawingPoint{X: 0, Y: 0}, End: story.DrawingPoint{X: 10, Y: 10}, Color: "#12345"},
+				},
+			},
+		},
+		http.StatusBadRequest,
+		"invalid segments at indexes 0 (invalid color \"blurple\"), 1 (invalid color \"#12345\").",
 	},
-
 	{
 		"Add a story: Fibbing It",
 		"fibbing_it",
@@ -82,6 +150,33 @@ var AddStories = []struct {
 			},
 		},
 		http.StatusCreated,
+		"",
+	},
+	{
+		"Try to add a story with negative votes: Quibly",
+		"quibly",
+		story.StoryInOut{
+			Question: "how many fish are there?",
+			Round:    "pair",
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				Quibly: []story.QuiblyAnswerInOut{
+					{Nickname: "funnyMan420", Answer: "one", Votes: -1},
+				},
+			},
+		},
+		http.StatusBadRequest,
+		"player funnyMan420 cannot have negative votes.",
+	},
+	{
+		"Add a story in progress without any answers: Drawlosseum",
+		"drawlosseum",
+		story.StoryInOut{
+			Question:   "fish",
+			Nickname:   "i_cannotDraw",
+			InProgress: true,
+		},
+		http.StatusCreated,
+		"",
 	},
 	{
 		"Story missing field exists",
@@ -92,9 +187,14 @@ var AddStories = []struct {
 			StoryAnswersInOut: story.StoryAnswersInOut{},
 		},
 		http.StatusBadRequest,
+		"",
 	},
 }
 
+// drawingCreatedAt is when the Drawlosseum story in the test data was added, the other stories don't have a
+// `created_at` so they use the time from their `_id`.
+var drawingCreatedAt = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
 var GetStories = []struct {
 	TestDescription string
 	GameName        string
@@ -110,6 +210,15 @@ var GetStories = []struct {
 		story.StoryInOut{
 			Question: "how many fish are there?",
 			Round:    "pair",
+			Results: &story.StoryResultsOut{
+				Winners:    []string{"funnyMan420"},
+				Tie:        false,
+				TotalVotes: 12341,
+				Ranks: []story.AnswerRankOut{
+					{Nickname: "funnyMan420", Answer: "one", Votes: 12341, Rank: 1, Share: 1},
+					{Nickname: "123456", Answer: "many", Votes: 0, Rank: 2, Share: 0},
+				},
+			},
 			StoryAnswersInOut: story.StoryAnswersInOut{
 				Quibly: []story.QuiblyAnswerInOut{
 					{
@@ -132,8 +241,9 @@ var GetStories = []struct {
 		"a4ffd1c8-93c5-4f4c-8ace-71996edcbcb7",
 		http.StatusOK,
 		story.StoryInOut{
-			Question: "fish",
-			Nickname: "i_cannotDraw",
+			Question:  "fish",
+			Nickname:  "i_cannotDraw",
+			CreatedAt: &drawingCreatedAt,
 			StoryAnswersInOut: story.StoryAnswersInOut{
 				Drawlosseum: story.DrawlosseumAnswersInOut{
 					{
@@ -191,3 +301,1369 @@ var DeleteStories = []struct {
 		http.StatusNotFound,
 	},
 }
+
+var ListStories = []struct {
+	TestDescription string
+	GameName        string
+	Query           map[string]interface{}
+	ExpectedStatus  int
+	ExpectedIDs     []string
+}{
+	{
+		"List stories newest first",
+		"fibbing_it",
+		map[string]interface{}{},
+		http.StatusOK,
+		[]string{
+			"8a7e92a9-2bc2-43f1-be33-2ff8645b227c",
+			"a5d158b5-7fc4-419b-8299-7363d1567840",
+			"479d0463-ed35-44bf-a976-801367be4246",
+		},
+	},
+	{
+		"List stories across multiple pages",
+		"fibbing_it",
+		map[string]interface{}{"limit": 2},
+		http.StatusOK,
+		[]string{
+			"8a7e92a9-2bc2-43f1-be33-2ff8645b227c",
+			"a5d158b5-7fc4-419b-8299-7363d1567840",
+			"479d0463-ed35-44bf-a976-801367be4246",
+		},
+	},
+	{
+		"List stories from a round",
+		"fibbing_it",
+		map[string]interface{}{"round": "opinion"},
+		http.StatusOK,
+		[]string{
+			"479d0463-ed35-44bf-a976-801367be4246",
+		},
+	},
+	{
+		"List stories for a question",
+		"fibbing_it",
+		map[string]interface{}{"question": "most likely to get arrested?"},
+		http.StatusOK,
+		[]string{
+			"8a7e92a9-2bc2-43f1-be33-2ff8645b227c",
+		},
+	},
+	{
+		"List stories with a player",
+		"quibly",
+		map[string]interface{}{"nickname": "funnyMan420"},
+		http.StatusOK,
+		[]string{
+			"1def4233-f674-4a3f-863d-6e850bfbfdb4",
+		},
+	},
+	{
+		"List stories added after a time",
+		"fibbing_it",
+		map[string]interface{}{"from": "2100-01-01T00:00:00Z"},
+		http.StatusOK,
+		[]string{},
+	},
+	{
+		"List stories added after a time, using when the stories were added",
+		"drawlosseum",
+		map[string]interface{}{"from": "2021-01-01T00:00:00Z"},
+		http.StatusOK,
+		[]string{},
+	},
+	{
+		"List stories added before a time, using when the stories were added",
+		"drawlosseum",
+		map[string]interface{}{"to": "2021-01-01T00:00:00Z"},
+		http.StatusOK,
+		[]string{
+			"a4ffd1c8-93c5-4f4c-8ace-71996edcbcb7",
+		},
+	},
+	{
+		"List stories with a player added after a time",
+		"quibly",
+		map[string]interface{}{"nickname": "funnyMan420", "from": "2021-01-01T00:00:00Z"},
+		http.StatusOK,
+		[]string{
+			"1def4233-f674-4a3f-863d-6e850bfbfdb4",
+		},
+	},
+	{
+		"List stories sorted by votes",
+		"quibly",
+		map[string]interface{}{"sort": "votes"},
+		http.StatusOK,
+		[]string{
+			"1def4233-f674-4a3f-863d-6e850bfbfdb4",
+		},
+	},
+	{
+		"Try to list stories sorted by votes for a game without votes",
+		"fibbing_it",
+		map[string]interface{}{"sort": "votes"},
+		http.StatusBadRequest,
+		[]string{},
+	},
+	{
+		"Try to list stories with an invalid cursor",
+		"fibbing_it",
+		map[string]interface{}{"cursor": "not-a-cursor"},
+		http.StatusBadRequest,
+		[]string{},
+	},
+	{
+		"Try to list stories with an invalid time",
+		"fibbing_it",
+		map[string]interface{}{"to": "yesterday"},
+		http.StatusBadRequest,
+		[]string{},
+	},
+	{
+		"Try to list stories of a game that doesn't exist",
+		"quiblyv3",
+		map[string]interface{}{},
+		http.StatusNotFound,
+		[]string{},
+	},
+}
+
+var AddStoryWithMetadata = []struct {
+	TestDescription string
+	GameName        string
+	Payload         story.StoryInOut
+	ExpectedStatus  int
+}{
+	{
+		"Add a story with metadata",
+		"fibbing_it",
+		story.StoryInOut{
+			Question: "What do you think about horses?",
+			Round:    "opinion",
+			Metadata: &story.StoryMetadataInOut{
+				RoomCode:    "ABCD",
+				Language:    "en",
+				PlayerCount: 4,
+				QuestionID:  "3e2889f6-56aa-4422-a7c5-033eafa9fd39",
+			},
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				FibbingIt: story.FibbingItAnswersInOut{
+					{Nickname: "!sus", Answer: "tasty"},
+					{Nickname: "normal_guy1", Answer: "lame"},
+				},
+			},
+		},
+		http.StatusCreated,
+	},
+	{
+		"Add a story without metadata",
+		"quibly",
+		story.StoryInOut{
+			Question: "how many fish are there?",
+			Round:    "pair",
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				Quibly: story.QuiblyAnswersInOut{
+					{Nickname: "funnyMan420", Answer: "one", Votes: 1},
+				},
+			},
+		},
+		http.StatusCreated,
+	},
+	{
+		"Try to add a story with an invalid language",
+		"fibbing_it",
+		story.StoryInOut{
+			Question: "What do you think about horses?",
+			Round:    "opinion",
+			Metadata: &story.StoryMetadataInOut{
+				Language: "not a language",
+			},
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				FibbingIt: story.FibbingItAnswersInOut{
+					{Nickname: "!sus", Answer: "tasty"},
+				},
+			},
+		},
+		http.StatusBadRequest,
+	},
+	{
+		"Try to add a story about a question of another game",
+		"fibbing_it",
+		story.StoryInOut{
+			Question: "this is a question?",
+			Round:    "opinion",
+			Metadata: &story.StoryMetadataInOut{
+				QuestionID: "4d18ac45-8034-4f8e-b636-cf730b17e51a",
+			},
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				FibbingIt: story.FibbingItAnswersInOut{
+					{Nickname: "!sus", Answer: "tasty"},
+				},
+			},
+		},
+		http.StatusBadRequest,
+	},
+	{
+		"Try to add a story about a question that hasn't been translated",
+		"fibbing_it",
+		story.StoryInOut{
+			Question: "What do you think about horses?",
+			Round:    "opinion",
+			Metadata: &story.StoryMetadataInOut{
+				Language:   "fr",
+				QuestionID: "3e2889f6-56aa-4422-a7c5-033eafa9fd39",
+			},
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				FibbingIt: story.FibbingItAnswersInOut{
+					{Nickname: "!sus", Answer: "tasty"},
+				},
+			},
+		},
+		http.StatusBadRequest,
+	},
+	{
+		"Try to add a story with a negative player count",
+		"fibbing_it",
+		story.StoryInOut{
+			Question: "What do you think about horses?",
+			Round:    "opinion",
+			Metadata: &story.StoryMetadataInOut{
+				PlayerCount: -1,
+			},
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				FibbingIt: story.FibbingItAnswersInOut{
+					{Nickname: "!sus", Answer: "tasty"},
+				},
+			},
+		},
+		http.StatusBadRequest,
+	},
+}
+
+var PurgeStories = []struct {
+	TestDescription string
+	GameName        string
+	Payload         interface{}
+	ExpectedStatus  int
+	ExpectedDeleted int
+}{
+	{
+		"Purge stories added before a time in the past",
+		"fibbing_it",
+		map[string]interface{}{"before": "2000-01-01T00:00:00Z"},
+		http.StatusOK,
+		0,
+	},
+	{
+		"Purge stories added before a time in the future",
+		"fibbing_it",
+		map[string]interface{}{"before": "2100-01-01T00:00:00Z"},
+		http.StatusOK,
+		3,
+	},
+	{
+		"Purge stories of a game that has already been purged",
+		"fibbing_it",
+		map[string]interface{}{"before": "2100-01-01T00:00:00Z"},
+		http.StatusOK,
+		0,
+	},
+	{
+		"Try to purge stories without a time",
+		"quibly",
+		map[string]interface{}{},
+		http.StatusBadRequest,
+		0,
+	},
+	{
+		"Try to purge stories of a game that doesn't exist",
+		"quiblyv3",
+		map[string]interface{}{"before": "2100-01-01T00:00:00Z"},
+		http.StatusNotFound,
+		0,
+	},
+}
+
+var GetStoryImage = []struct {
+	TestDescription     string
+	GameName            string
+	StoryID             string
+	Format              string
+	ExpectedStatus      int
+	ExpectedContentType string
+}{
+	{
+		"Get a drawing as an SVG",
+		"drawlosseum",
+		"a4ffd1c8-93c5-4f4c-8ace-71996edcbcb7",
+		"svg",
+		http.StatusOK,
+		"image/svg+xml",
+	},
+	{
+		"Get a drawing as a PNG",
+		"drawlosseum",
+		"a4ffd1c8-93c5-4f4c-8ace-71996edcbcb7",
+		"png",
+		http.StatusOK,
+		"image/png",
+	},
+	{
+		"Try to get a drawing in an invalid format",
+		"drawlosseum",
+		"a4ffd1c8-93c5-4f4c-8ace-71996edcbcb7",
+		"gif",
+		http.StatusBadRequest,
+		"",
+	},
+	{
+		"Try to get a drawing that doesn't exist",
+		"drawlosseum",
+		"a4ffd1c8-93c5-4f4c-8ace-71996edcbcb8",
+		"svg",
+		http.StatusNotFound,
+		"",
+	},
+	{
+		"Try to get a story that isn't a drawing as an image",
+		"fibbing_it",
+		"479d0463-ed35-44bf-a976-801367be4246",
+		"svg",
+		http.StatusBadRequest,
+		"",
+	},
+}
+
+var CompactDrawings = []struct {
+	TestDescription  string
+	LegacyStories    []map[string]interface{}
+	BatchSize        int64
+	ExpectedMigrated int
+	ExpectedResult   story.StoryInOut
+}{
+	{
+		"Migrate a drawing stored as a list of segments",
+		[]map[string]interface{}{
+			{
+				"id":        "0b5dd5b3-0b8c-4b2e-8e8c-0f2f6e4a6a9e",
+				"game_name": "drawlosseum",
+				"question":  "horse",
+				"nickname":  "i_cannotDraw",
+				"answers": []map[string]interface{}{
+					{
+						"start": map[string]interface{}{"x": 10.5, "y": -20.25},
+						"end":   map[string]interface{}{"x": 30, "y": 40},
+						"color": "#ff0000",
+					},
+					{
+						"start": map[string]interface{}{"x": 30, "y": 40},
+						"end":   map[string]interface{}{"x": -50, "y": 60},
+						"color": "#ff0000",
+					},
+					{
+						"start": map[string]interface{}{"x": 0, "y": 0},
+						"end":   map[string]interface{}{"x": 1, "y": 1},
+						"color": "blue",
+					},
+				},
+			},
+		},
+		100,
+		1,
+		story.StoryInOut{
+			Question: "horse",
+			Nickname: "i_cannotDraw",
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				Drawlosseum: story.DrawlosseumAnswersInOut{
+					{Start: story.DrawingPoint{X: 10.5, Y: -20.25}, End: story.DrawingPoint{X: 30, Y: 40}, Color: "#ff0000"},
+					{Start: story.DrawingPoint{X: 30, Y: 40}, End: story.DrawingPoint{X: -50, Y: 60}, Color: "#ff0000"},
+					{Start: story.DrawingPoint{X: 0, Y: 0}, End: story.DrawingPoint{X: 1, Y: 1}, Color: "blue"},
+				},
+			},
+		},
+	},
+	{
+		"Migrate drawings without losing precision, over several batches",
+		[]map[string]interface{}{
+			legacyDrawing("5c1f0f7e-3f0e-4a53-9d3c-2f1d1c7b8a01", -12.345, 0.001),
+			legacyDrawing("5c1f0f7e-3f0e-4a53-9d3c-2f1d1c7b8a02", 1, 2),
+			legacyDrawing("5c1f0f7e-3f0e-4a53-9d3c-2f1d1c7b8a03", 3, 4),
+		},
+		2,
+		3,
+		story.StoryInOut{
+			Question: "fish",
+			Nickname: "i_cannotDraw",
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				Drawlosseum: story.DrawlosseumAnswersInOut{
+					{Start: story.DrawingPoint{X: 0, Y: 0}, End: story.DrawingPoint{X: -12.345, Y: 0.001}, Color: "#000"},
+				},
+			},
+		},
+	},
+}
+
+// legacyDrawing is a Drawlosseum story with a single segment, stored as a list of segments.
+func legacyDrawing(id string, x float32, y float32) map[string]interface{} {
+	return map[string]interface{}{
+		"id":        id,
+		"game_name": "drawlosseum",
+		"question":  "fish",
+		"nickname":  "i_cannotDraw",
+		"answers": []map[string]interface{}{
+			{
+				"start": map[string]interface{}{"x": 0, "y": 0},
+				"end":   map[string]interface{}{"x": x, "y": y},
+				"color": "#000",
+			},
+		},
+	}
+}
+
+var GetQuiblyResults = []struct {
+	TestDescription string
+	Payload         story.StoryInOut
+	ExpectedResults story.StoryResultsOut
+}{
+	{
+		"Get the results of a story with a tie",
+		story.StoryInOut{
+			Question: "What is the best bike?",
+			Round:    "pair",
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				Quibly: story.QuiblyAnswersInOut{
+					{Nickname: "Majiy", Answer: "a bmx", Votes: 3},
+					{Nickname: "CanIHaveAName", Answer: "a unicycle", Votes: 1},
+					{Nickname: "!sus", Answer: "a tandem", Votes: 3},
+					{Nickname: "normal_guy1", Answer: "a penny farthing", Votes: 1},
+				},
+			},
+		},
+		story.StoryResultsOut{
+			Winners:    []string{"Majiy", "!sus"},
+			Tie:        true,
+			TotalVotes: 8,
+			Ranks: []story.AnswerRankOut{
+				{Nickname: "Majiy", Answer: "a bmx", Votes: 3, Rank: 1, Share: 0.375},
+				{Nickname: "!sus", Answer: "a tandem", Votes: 3, Rank: 1, Share: 0.375},
+				{Nickname: "CanIHaveAName", Answer: "a unicycle", Votes: 1, Rank: 3, Share: 0.125},
+				{Nickname: "normal_guy1", Answer: "a penny farthing", Votes: 1, Rank: 3, Share: 0.125},
+			},
+		},
+	},
+	{
+		"Get the results of a story without votes",
+		story.StoryInOut{
+			Question: "What is the best bike?",
+			Round:    "pair",
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				Quibly: story.QuiblyAnswersInOut{
+					{Nickname: "Majiy", Answer: "a bmx"},
+					{Nickname: "!sus", Answer: "a tandem"},
+				},
+			},
+		},
+		story.StoryResultsOut{
+			Winners:    []string{},
+			Tie:        false,
+			TotalVotes: 0,
+			Ranks: []story.AnswerRankOut{
+				{Nickname: "Majiy", Answer: "a bmx", Votes: 0, Rank: 1, Share: 0},
+				{Nickname: "!sus", Answer: "a tandem", Votes: 0, Rank: 1, Share: 0},
+			},
+		},
+	},
+}
+
+var QuiblyStories = []story.StoryInOut{
+	{
+		Question: "What is the best bike?",
+		Round:    "pair",
+		StoryAnswersInOut: story.StoryAnswersInOut{
+			Quibly: story.QuiblyAnswersInOut{
+				{Nickname: "Majiy", Answer: "a bmx", Votes: 2},
+				{Nickname: "!sus", Answer: "a tandem", Votes: 6},
+			},
+		},
+	},
+	{
+		Question: "What is the worst pizza topping?",
+		Round:    "group",
+		StoryAnswersInOut: story.StoryAnswersInOut{
+			Quibly: story.QuiblyAnswersInOut{
+				{Nickname: "Majiy", Answer: "pineapple", Votes: 5},
+				{Nickname: "!sus", Answer: "glue", Votes: 5},
+			},
+		},
+	},
+	{
+		Question: "What is in the box?",
+		Round:    "answers",
+		StoryAnswersInOut: story.StoryAnswersInOut{
+			Quibly: story.QuiblyAnswersInOut{
+				{Nickname: "Majiy", Answer: "nothing", Votes: 0},
+			},
+		},
+	},
+}
+
+var GetBestAnswers = []struct {
+	TestDescription string
+	GameName        string
+	Nickname        string
+	Query           map[string]interface{}
+	ExpectedStatus  int
+	ExpectedAnswers []story.AnswerRankOut
+}{
+	{
+		"Get the best answers of a player",
+		"quibly",
+		"Majiy",
+		map[string]interface{}{},
+		http.StatusOK,
+		[]story.AnswerRankOut{
+			{Nickname: "Majiy", Answer: "pineapple", Votes: 5, Rank: 1, Share: 0.5},
+			{Nickname: "Majiy", Answer: "a bmx", Votes: 2, Rank: 2, Share: 0.25},
+			{Nickname: "Majiy", Answer: "nothing", Votes: 0, Rank: 1, Share: 0},
+		},
+	},
+	{
+		"Get the best answer of a player",
+		"quibly",
+		"!sus",
+		map[string]interface{}{"limit": 1},
+		http.StatusOK,
+		[]story.AnswerRankOut{
+			{Nickname: "!sus", Answer: "a tandem", Votes: 6, Rank: 1, Share: 0.75},
+		},
+	},
+	{
+		"Get the best answers of a player without any answers",
+		"quibly",
+		"nobody",
+		map[string]interface{}{},
+		http.StatusOK,
+		[]story.AnswerRankOut{},
+	},
+	{
+		"Try to get the best answers of a game without votes",
+		"fibbing_it",
+		"!sus",
+		map[string]interface{}{},
+		http.StatusBadRequest,
+		[]story.AnswerRankOut{},
+	},
+	{
+		"Try to get the best answers of a game that doesn't exist",
+		"quiblyv3",
+		"Majiy",
+		map[string]interface{}{},
+		http.StatusNotFound,
+		[]story.AnswerRankOut{},
+	},
+}
+
+var AppendAnswers = []struct {
+	TestDescription string
+	GameName        string
+	Payload         story.StoryInOut
+	Answers         story.StoryAnswersInOut
+	ExpectedStatus  int
+	ExpectedAnswers story.StoryAnswersInOut
+}{
+	{
+		"Append answers and votes to a story: Quibly",
+		"quibly",
+		story.StoryInOut{
+			Question:   "What is the best bike?",
+			Round:      "pair",
+			InProgress: true,
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				Quibly: story.QuiblyAnswersInOut{
+					{Nickname: "Majiy", Answer: "a bmx"},
+				},
+			},
+		},
+		story.StoryAnswersInOut{
+			Quibly: story.QuiblyAnswersInOut{
+				{Nickname: "!sus", Answer: "a tandem", Votes: 1},
+				{Nickname: "Majiy", Votes: 2},
+			},
+		},
+		http.StatusOK,
+		story.StoryAnswersInOut{
+			Quibly: story.QuiblyAnswersInOut{
+				{Nickname: "Majiy", Answer: "a bmx", Votes: 2},
+				{Nickname: "!sus", Answer: "a tandem", Votes: 1},
+			},
+		},
+	},
+	{
+		"Append answers to a story: Fibbing It",
+		"fibbing_it",
+		story.StoryInOut{
+			Question:   "What do you think about horses?",
+			Round:      "opinion",
+			InProgress: true,
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				FibbingIt: story.FibbingItAnswersInOut{
+					{Nickname: "!sus", Answer: "tasty"},
+				},
+			},
+		},
+		story.StoryAnswersInOut{
+			FibbingIt: story.FibbingItAnswersInOut{
+				{Nickname: "normal_guy1", Answer: "lame"},
+			},
+		},
+		http.StatusOK,
+		story.StoryAnswersInOut{
+			FibbingIt: story.FibbingItAnswersInOut{
+				{Nickname: "!sus", Answer: "tasty"},
+				{Nickname: "normal_guy1", Answer: "lame"},
+			},
+		},
+	},
+	{
+		"Append segments to a drawing: Drawlosseum",
+		"drawlosseum",
+		story.StoryInOut{
+			Question:   "fish",
+			Nickname:   "i_cannotDraw",
+			InProgress: true,
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				Drawlosseum: story.DrawlosseumAnswersInOut{
+					{Start: story.DrawingPoint{X: 100, Y: -100}, End: story.DrawingPoint{X: 90, Y: -100}, Color: "#000"},
+				},
+			},
+		},
+		story.StoryAnswersInOut{
+			Drawlosseum: story.DrawlosseumAnswersInOut{
+				{Start: story.DrawingPoint{X: 90, Y: -100}, End: story.DrawingPoint{X: 90, Y: -90}, Color: "#000"},
+			},
+		},
+		http.StatusOK,
+		story.StoryAnswersInOut{
+			Drawlosseum: story.DrawlosseumAnswersInOut{
+				{Start: story.DrawingPoint{X: 100, Y: -100}, End: story.DrawingPoint{X: 90, Y: -100}, Color: "#000"},
+				{Start: story.DrawingPoint{X: 90, Y: -100}, End: story.DrawingPoint{X: 90, Y: -90}, Color: "#000"},
+			},
+		},
+	},
+	{
+		"Try to append invalid segments to a drawing: Drawlosseum",
+		"drawlosseum",
+		story.StoryInOut{
+			Question:   "fish",
+			Nickname:   "i_cannotDraw",
+			InProgress: true,
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				Drawlosseum: story.DrawlosseumAnswersInOut{
+					{Start: story.DrawingPoint{X: 100, Y: -100}, End: story.DrawingPoint{X: 90, Y: -100}, Color: "#000"},
+				},
+			},
+		},
+		story.StoryAnswersInOut{
+			Drawlosseum: story.DrawlosseumAnswersInOut{
+				{Start: story.DrawingPoint{X: 90, Y: -100}, End: story.DrawingPoint{X: 9000, Y: -90}, Color: "#000"},
+			},
+		},
+		http.StatusBadRequest,
+		story.StoryAnswersInOut{},
+	},
+	{
+		"Try to change the answer of a player: Quibly",
+		"quibly",
+		story.StoryInOut{
+			Question:   "What is the best bike?",
+			Round:      "pair",
+			InProgress: true,
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				Quibly: story.QuiblyAnswersInOut{
+					{Nickname: "Majiy", Answer: "a bmx"},
+				},
+			},
+		},
+		story.StoryAnswersInOut{
+			Quibly: story.QuiblyAnswersInOut{
+				{Nickname: "Majiy", Answer: "a unicycle"},
+			},
+		},
+		http.StatusBadRequest,
+		story.StoryAnswersInOut{},
+	},
+	{
+		"Append answers to a story that was started without any: Fibbing It",
+		"fibbing_it",
+		story.StoryInOut{
+			Question:   "What do you think about horses?",
+			Round:      "opinion",
+			InProgress: true,
+		},
+		story.StoryAnswersInOut{
+			FibbingIt: story.FibbingItAnswersInOut{
+				{Nickname: "normal_guy1", Answer: "lame"},
+			},
+		},
+		http.StatusOK,
+		story.StoryAnswersInOut{
+			FibbingIt: story.FibbingItAnswersInOut{
+				{Nickname: "normal_guy1", Answer: "lame"},
+			},
+		},
+	},
+	{
+		"Try to append another answer of a player: Fibbing It",
+		"fibbing_it",
+		story.StoryInOut{
+			Question:   "What do you think about horses?",
+			Round:      "opinion",
+			InProgress: true,
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				FibbingIt: story.FibbingItAnswersInOut{
+					{Nickname: "!sus", Answer: "tasty"},
+				},
+			},
+		},
+		story.StoryAnswersInOut{
+			FibbingIt: story.FibbingItAnswersInOut{
+				{Nickname: "!sus", Answer: "lame"},
+			},
+		},
+		http.StatusBadRequest,
+		story.StoryAnswersInOut{},
+	},
+	{
+		"Try to append two answers of the same player: Fibbing It",
+		"fibbing_it",
+		story.StoryInOut{
+			Question:   "What do you think about horses?",
+			Round:      "opinion",
+			InProgress: true,
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				FibbingIt: story.FibbingItAnswersInOut{
+					{Nickname: "!sus", Answer: "tasty"},
+				},
+			},
+		},
+		story.StoryAnswersInOut{
+			FibbingIt: story.FibbingItAnswersInOut{
+				{Nickname: "normal_guy1", Answer: "lame"},
+				{Nickname: "normal_guy1", Answer: "boring"},
+			},
+		},
+		http.StatusBadRequest,
+		story.StoryAnswersInOut{},
+	},
+	{
+		"Try to append negative votes: Quibly",
+		"quibly",
+		story.StoryInOut{
+			Question:   "What is the best bike?",
+			Round:      "pair",
+			InProgress: true,
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				Quibly: story.QuiblyAnswersInOut{
+					{Nickname: "Majiy", Answer: "a bmx", Votes: 3},
+				},
+			},
+		},
+		story.StoryAnswersInOut{
+			Quibly: story.QuiblyAnswersInOut{
+				{Nickname: "Majiy", Votes: -2},
+			},
+		},
+		http.StatusBadRequest,
+		story.StoryAnswersInOut{},
+	},
+	{
+		"Try to append no answers to a story",
+		"fibbing_it",
+		story.StoryInOut{
+			Question:   "What do you think about horses?",
+			Round:      "opinion",
+			InProgress: true,
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				FibbingIt: story.FibbingItAnswersInOut{
+					{Nickname: "!sus", Answer: "tasty"},
+				},
+			},
+		},
+		story.StoryAnswersInOut{},
+		http.StatusBadRequest,
+		story.StoryAnswersInOut{},
+	},
+	{
+		"Try to append answers to a complete story",
+		"fibbing_it",
+		story.StoryInOut{
+			Question: "What do you think about horses?",
+			Round:    "opinion",
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				FibbingIt: story.FibbingItAnswersInOut{
+					{Nickname: "!sus", Answer: "tasty"},
+				},
+			},
+		},
+		story.StoryAnswersInOut{
+			FibbingIt: story.FibbingItAnswersInOut{
+				{Nickname: "normal_guy1", Answer: "lame"},
+			},
+		},
+		http.StatusBadRequest,
+		story.StoryAnswersInOut{},
+	},
+}
+
+var AppendAnswersConcurrently = []struct {
+	TestDescription string
+	GameName        string
+	Payload         story.StoryInOut
+	Answers         []story.StoryAnswersInOut
+	ExpectedAnswers []interface{}
+}{
+	{
+		"Append answers of every player at the same time: Fibbing It",
+		"fibbing_it",
+		story.StoryInOut{
+			Question:   "What do you think about horses?",
+			Round:      "opinion",
+			InProgress: true,
+		},
+		[]story.StoryAnswersInOut{
+			{FibbingIt: story.FibbingItAnswersInOut{{Nickname: "!sus", Answer: "tasty"}}},
+			{FibbingIt: story.FibbingItAnswersInOut{{Nickname: "normal_guy1", Answer: "lame"}}},
+			{FibbingIt: story.FibbingItAnswersInOut{{Nickname: "normal_girl1", Answer: "lame"}}},
+			{FibbingIt: story.FibbingItAnswersInOut{{Nickname: "normal_person1", Answer: "fast"}}},
+			{FibbingIt: story.FibbingItAnswersInOut{{Nickname: "Majiy", Answer: "smelly"}}},
+			{FibbingIt: story.FibbingItAnswersInOut{{Nickname: "CanIHaveAName", Answer: "lame"}}},
+		},
+		[]interface{}{
+			story.FibbingItAnswerInOut{Nickname: "!sus", Answer: "tasty"},
+			story.FibbingItAnswerInOut{Nickname: "normal_guy1", Answer: "lame"},
+			story.FibbingItAnswerInOut{Nickname: "normal_girl1", Answer: "lame"},
+			story.FibbingItAnswerInOut{Nickname: "normal_person1", Answer: "fast"},
+			story.FibbingItAnswerInOut{Nickname: "Majiy", Answer: "smelly"},
+			story.FibbingItAnswerInOut{Nickname: "CanIHaveAName", Answer: "lame"},
+		},
+	},
+	{
+		"Append answers and votes at the same time: Quibly",
+		"quibly",
+		story.StoryInOut{
+			Question:   "What is the best bike?",
+			Round:      "pair",
+			InProgress: true,
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				Quibly: story.QuiblyAnswersInOut{
+					{Nickname: "Majiy", Answer: "a bmx"},
+				},
+			},
+		},
+		[]story.StoryAnswersInOut{
+			{Quibly: story.QuiblyAnswersInOut{{Nickname: "Majiy", Votes: 1}}},
+			{Quibly: story.QuiblyAnswersInOut{{Nickname: "!sus", Answer: "a tandem"}}},
+			{Quibly: story.QuiblyAnswersInOut{{Nickname: "Majiy", Votes: 1}}},
+			{Quibly: story.QuiblyAnswersInOut{{Nickname: "Majiy", Votes: 2}}},
+			{Quibly: story.QuiblyAnswersInOut{{Nickname: "normal_guy1", Answer: "a unicycle"}}},
+			{Quibly: story.QuiblyAnswersInOut{{Nickname: "Majiy", Votes: 1}}},
+		},
+		[]interface{}{
+			story.QuiblyAnswerInOut{Nickname: "Majiy", Answer: "a bmx", Votes: 5},
+			story.QuiblyAnswerInOut{Nickname: "!sus", Answer: "a tandem", Votes: 0},
+			story.QuiblyAnswerInOut{Nickname: "normal_guy1", Answer: "a unicycle", Votes: 0},
+		},
+	},
+	{
+		"Append segments at the same time: Drawlosseum",
+		"drawlosseum",
+		story.StoryInOut{
+			Question:   "fish",
+			Nickname:   "i_cannotDraw",
+			InProgress: true,
+		},
+		[]story.StoryAnswersInOut{
+			{Drawlosseum: story.DrawlosseumAnswersInOut{{Start: story.DrawingPoint{X: 0, Y: 0}, End: story.DrawingPoint{X: 10, Y: 0}, Color: "#000"}}},
+			{Drawlosseum: story.DrawlosseumAnswersInOut{{Start: story.DrawingPoint{X: 10, Y: 0}, End: story.DrawingPoint{X: 10, Y: 10}, Color: "#000"}}},
+			{Drawlosseum: story.DrawlosseumAnswersInOut{{Start: story.DrawingPoint{X: 10, Y: 10}, End: story.DrawingPoint{X: 0, Y: 10}, Color: "#000"}}},
+			{Drawlosseum: story.DrawlosseumAnswersInOut{{Start: story.DrawingPoint{X: 0, Y: 10}, End: story.DrawingPoint{X: 0, Y: 0}, Color: "#000"}}},
+			{Drawlosseum: story.DrawlosseumAnswersInOut{{Start: story.DrawingPoint{X: 0, Y: 0}, End: story.DrawingPoint{X: 10, Y: 10}, Color: "#000"}}},
+			{Drawlosseum: story.DrawlosseumAnswersInOut{{Start: story.DrawingPoint{X: 10, Y: 0}, End: story.DrawingPoint{X: 0, Y: 10}, Color: "#000"}}},
+		},
+		[]interface{}{
+			story.CaertsianCoordinateColor{Start: story.DrawingPoint{X: 0, Y: 0}, End: story.DrawingPoint{X: 10, Y: 0}, Color: "#000"},
+			story.CaertsianCoordinateColor{Start: story.DrawingPoint{X: 10, Y: 0}, End: story.DrawingPoint{X: 10, Y: 10}, Color: "#000"},
+			story.CaertsianCoordinateColor{Start: story.DrawingPoint{X: 10, Y: 10}, End: story.DrawingPoint{X: 0, Y: 10}, Color: "#000"},
+			story.CaertsianCoordinateColor{Start: story.DrawingPoint{X: 0, Y: 10}, End: story.DrawingPoint{X: 0, Y: 0}, Color: "#000"},
+			story.CaertsianCoordinateColor{Start: story.DrawingPoint{X: 0, Y: 0}, End: story.DrawingPoint{X: 10, Y: 10}, Color: "#000"},
+			story.CaertsianCoordinateColor{Start: story.DrawingPoint{X: 10, Y: 0}, End: story.DrawingPoint{X: 0, Y: 10}, Color: "#000"},
+		},
+	},
+}
+
+var FinaliseStory = []struct {
+	TestDescription string
+	Payload         story.StoryInOut
+	Finalise        int
+	ExpectedStatus  int
+}{
+	{
+		"Finalise a story in progress",
+		story.StoryInOut{
+			Question:   "What do you think about horses?",
+			Round:      "opinion",
+			InProgress: true,
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				FibbingIt: story.FibbingItAnswersInOut{
+					{Nickname: "!sus", Answer: "tasty"},
+				},
+			},
+		},
+		1,
+		http.StatusOK,
+	},
+	{
+		"Finalise a story that was started without any answers",
+		story.StoryInOut{
+			Question:   "What do you think about horses?",
+			Round:      "opinion",
+			InProgress: true,
+		},
+		1,
+		http.StatusOK,
+	},
+	{
+		"Try to finalise a story twice",
+		story.StoryInOut{
+			Question:   "What do you think about horses?",
+			Round:      "opinion",
+			InProgress: true,
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				FibbingIt: story.FibbingItAnswersInOut{
+					{Nickname: "!sus", Answer: "tasty"},
+				},
+			},
+		},
+		2,
+		http.StatusBadRequest,
+	},
+	{
+		"Try to finalise a complete story",
+		story.StoryInOut{
+			Question: "What do you think about horses?",
+			Round:    "opinion",
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				FibbingIt: story.FibbingItAnswersInOut{
+					{Nickname: "!sus", Answer: "tasty"},
+				},
+			},
+		},
+		1,
+		http.StatusBadRequest,
+	},
+}
+
+var ShareStory = []struct {
+	TestDescription string
+	GameName        string
+	StoryID         string
+	Payload         interface{}
+	ExpectedStatus  int
+	ExpectedResult  story.StoryInOut
+}{
+	{
+		"Share a story",
+		"fibbing_it",
+		"479d0463-ed35-44bf-a976-801367be4246",
+		map[string]interface{}{},
+		http.StatusCreated,
+		story.StoryInOut{
+			Question: "What do you think about horses?",
+			Round:    "opinion",
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				FibbingIt: story.FibbingItAnswersInOut{
+					{Answer: "tasty", Nickname: "!sus"},
+					{Answer: "lame", Nickname: "normal_guy1"},
+					{Answer: "lame", Nickname: "normal_girl1"},
+					{Answer: "lame", Nickname: "normal_person1"},
+				},
+			},
+		},
+	},
+	{
+		"Share a story with masked nicknames",
+		"quibly",
+		"1def4233-f674-4a3f-863d-6e850bfbfdb4",
+		map[string]interface{}{"mask_nicknames": true, "expires_at": "2100-01-01T00:00:00Z"},
+		http.StatusCreated,
+		story.StoryInOut{
+			Question: "how many fish are there?",
+			Round:    "pair",
+			Results: &story.StoryResultsOut{
+				Winners:    []string{"Player 1"},
+				Tie:        false,
+				TotalVotes: 12341,
+				Ranks: []story.AnswerRankOut{
+					{Nickname: "Player 1", Answer: "one", Votes: 12341, Rank: 1, Share: 1},
+					{Nickname: "Player 2", Answer: "many", Votes: 0, Rank: 2, Share: 0},
+				},
+			},
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				Quibly: story.QuiblyAnswersInOut{
+					{Nickname: "Player 1", Answer: "one", Votes: 12341},
+					{Nickname: "Player 2", Answer: "many", Votes: 0},
+				},
+			},
+		},
+	},
+	{
+		"Share a story with masked nicknames, round likely",
+		"fibbing_it",
+		"8a7e92a9-2bc2-43f1-be33-2ff8645b227c",
+		map[string]interface{}{"mask_nicknames": true},
+		http.StatusCreated,
+		story.StoryInOut{
+			Question: "most likely to get arrested?",
+			Round:    "likely",
+			StoryAnswersInOut: story.StoryAnswersInOut{
+				FibbingIt: story.FibbingItAnswersInOut{
+					{Answer: "Player 2", Nickname: "Player 1"},
+					{Answer: "Player 3", Nickname: "Player 2"},
+					{Answer: "Player 1", Nickname: "Player 3"},
+					{Answer: "Player 3", Nickname: "Player 4"},
+				},
+			},
+		},
+	},
+	{
+		"Try to share a story with an expiry time in the past",
+		"quibly",
+		"1def4233-f674-4a3f-863d-6e850bfbfdb4",
+		map[string]interface{}{"expires_at": "2000-01-01T00:00:00Z"},
+		http.StatusBadRequest,
+		story.StoryInOut{},
+	},
+	{
+		"Try to share a story that doesn't exist",
+		"quibly",
+		"50-011c-45d8-98f7-819520c253b6",
+		map[string]interface{}{},
+		http.StatusNotFound,
+		story.StoryInOut{},
+	},
+}
+
+var ErasePlayer = []struct {
+	TestDescription string
+	Payload         map[string]interface{}
+	ExpectedStatus  int
+	ExpectedStories []story.ErasedStoryOut
+}{
+	{
+		"Redact a player",
+		map[string]interface{}{"nickname": "!sus"},
+		http.StatusOK,
+		[]story.ErasedStoryOut{
+			{GameName: "fibbing_it", StoryID: "479d0463-ed35-44bf-a976-801367be4246", Mentions: 1},
+			{GameName: "fibbing_it", StoryID: "a5d158b5-7fc4-419b-8299-7363d1567840", Mentions: 1},
+			{GameName: "fibbing_it", StoryID: "8a7e92a9-2bc2-43f1-be33-2ff8645b227c", Mentions: 2},
+		},
+	},
+	{
+		"Erase a player from stories added after a time, using when the stories were added",
+		map[string]interface{}{"nickname": "i_cannotDraw", "from": "2021-01-01T00:00:00Z"},
+		http.StatusOK,
+		[]story.ErasedStoryOut{},
+	},
+	{
+		"Delete the stories of a player in a game",
+		map[string]interface{}{"nickname": "i_cannotDraw", "game_name": "drawlosseum", "action": "delete"},
+		http.StatusOK,
+		[]story.ErasedStoryOut{
+			{GameName: "drawlosseum", StoryID: "a4ffd1c8-93c5-4f4c-8ace-71996edcbcb7", Mentions: 1},
+		},
+	},
+	{
+		"Erase a player from stories added after a time",
+		map[string]interface{}{"nickname": "!sus", "from": "2100-01-01T00:00:00Z"},
+		http.StatusOK,
+		[]story.ErasedStoryOut{},
+	},
+	{
+		"Erase a player without any stories",
+		map[string]interface{}{"nickname": "nobody"},
+		http.StatusOK,
+		[]story.ErasedStoryOut{},
+	},
+	{
+		"Try to erase a player with an invalid action",
+		map[string]interface{}{"nickname": "!sus", "action": "hide"},
+		http.StatusBadRequest,
+		[]story.ErasedStoryOut{},
+	},
+	{
+		"Try to erase a player without a nickname",
+		map[string]interface{}{"action": "delete"},
+		http.StatusBadRequest,
+		[]story.ErasedStoryOut{},
+	},
+	{
+		"Try to erase a player from a game that doesn't exist",
+		map[string]interface{}{"nickname": "!sus", "game_name": "quiblyv3"},
+		http.StatusNotFound,
+		[]story.ErasedStoryOut{},
+	},
+}
+
+var GetStoryTranscript = []struct {
+	TestDescription     string
+	GameName            string
+	StoryID             string
+	Format              string
+	ExpectedStatus      int
+	ExpectedContentType string
+	ExpectedContent     []string
+}{
+	{
+		"Get a transcript as Markdown: Quibly",
+		"quibly",
+		"1def4233-f674-4a3f-863d-6e850bfbfdb4",
+		"markdown",
+		http.StatusOK,
+		"text/markdown",
+		[]string{"# how many fish are there?", "- **funnyMan420**: one (12341 votes)", "- **123456**: many (0 votes)"},
+	},
+	{
+		"Get a transcript as HTML: Fibbing It",
+		"fibbing_it",
+		"479d0463-ed35-44bf-a976-801367be4246",
+		"html",
+		http.StatusOK,
+		"text/html",
+		[]string{"<h1>What do you think about horses?</h1>", "<li><strong>!sus</strong>: tasty</li>"},
+	},
+	{
+		"Get a transcript with a drawing as HTML: Drawlosseum",
+		"drawlosseum",
+		"a4ffd1c8-93c5-4f4c-8ace-71996edcbcb7",
+		"html",
+		http.StatusOK,
+		"text/html",
+		[]string{"<h1>fish</h1>", "<strong>i_cannotDraw</strong>", "<figure><svg"},
+	},
+	{
+		"Get a transcript with a drawing as Markdown: Drawlosseum",
+		"drawlosseum",
+		"a4ffd1c8-93c5-4f4c-8ace-71996edcbcb7",
+		"markdown",
+		http.StatusOK,
+		"text/markdown",
+		[]string{"# fish", "By **i\\_cannotDraw**", "![Drawing](data:image/svg+xml;base64,"},
+	},
+	{
+		"Try to get a transcript in an invalid format",
+		"quibly",
+		"1def4233-f674-4a3f-863d-6e850bfbfdb4",
+		"pdf",
+		http.StatusBadRequest,
+		"",
+		[]string{},
+	},
+	{
+		"Try to get a transcript of a story that doesn't exist",
+		"quibly",
+		"50-011c-45d8-98f7-819520c253b6",
+		"markdown",
+		http.StatusNotFound,
+		"",
+		[]string{},
+	},
+}
+
+var LikeStory = []struct {
+	TestDescription string
+	GameName        string
+	StoryID         string
+	Likes           int
+	Unlikes         int
+	ExpectedStatus  int
+	ExpectedLikes   int
+}{
+	{
+		"Like a story three times",
+		"fibbing_it",
+		"479d0463-ed35-44bf-a976-801367be4246",
+		3,
+		0,
+		http.StatusOK,
+		3,
+	},
+	{
+		"Like and unlike a story",
+		"quibly",
+		"1def4233-f674-4a3f-863d-6e850bfbfdb4",
+		2,
+		1,
+		http.StatusOK,
+		1,
+	},
+	{
+		"Unlike a story without likes",
+		"drawlosseum",
+		"a4ffd1c8-93c5-4f4c-8ace-71996edcbcb7",
+		0,
+		2,
+		http.StatusOK,
+		0,
+	},
+	{
+		"Try to like a story that does not exist",
+		"quibly",
+		"479d0463-ed35-44bf-a976-801367be4246",
+		1,
+		0,
+		http.StatusNotFound,
+		0,
+	},
+}
+
+var FeatureStory = []struct {
+	TestDescription string
+	GameName        string
+	StoryID         string
+	Payload         interface{}
+	ExpectedStatus  int
+	ExpectedResult  bool
+}{
+	{
+		"Feature a story",
+		"fibbing_it",
+		"479d0463-ed35-44bf-a976-801367be4246",
+		map[string]interface{}{"featured": true},
+		http.StatusOK,
+		true,
+	},
+	{
+		"Feature a story that is already featured",
+		"fibbing_it",
+		"479d0463-ed35-44bf-a976-801367be4246",
+		map[string]interface{}{"featured": true},
+		http.StatusOK,
+		true,
+	},
+	{
+		"Stop featuring a story",
+		"fibbing_it",
+		"479d0463-ed35-44bf-a976-801367be4246",
+		map[string]interface{}{"featured": false},
+		http.StatusOK,
+		false,
+	},
+	{
+		"Try to feature a story that does not exist",
+		"quibly",
+		"479d0463-ed35-44bf-a976-801367be4246",
+		map[string]interface{}{"featured": true},
+		http.StatusNotFound,
+		false,
+	},
+}
+
+var HighlightStories = []story.StoryInOut{
+	{
+		Question: "What is the best bike?",
+		Round:    "pair",
+		StoryAnswersInOut: story.StoryAnswersInOut{
+			Quibly: story.QuiblyAnswersInOut{
+				{Nickname: "Majiy", Answer: "a unicycle", Votes: 2},
+				{Nickname: "Sam", Answer: "a tandem", Votes: 1},
+			},
+		},
+	},
+	{
+		Question: "What is the worst bike?",
+		Round:    "pair",
+		StoryAnswersInOut: story.StoryAnswersInOut{
+			Quibly: story.QuiblyAnswersInOut{
+				{Nickname: "Majiy", Answer: "a penny farthing", Votes: 0},
+				{Nickname: "Sam", Answer: "a tricycle", Votes: 3},
+			},
+		},
+	},
+	{
+		Question: "Where should you ride a bike?",
+		Round:    "group",
+		StoryAnswersInOut: story.StoryAnswersInOut{
+			Quibly: story.QuiblyAnswersInOut{
+				{Nickname: "Majiy", Answer: "the moon", Votes: 1},
+				{Nickname: "Sam", Answer: "the sea", Votes: 1},
+			},
+		},
+	},
+}
+
+var GetHighlights = []struct {
+	TestDescription  string
+	GameName         string
+	From             string
+	To               string
+	ExpectedStatus   int
+	ExpectedFeatured []string
+	ExpectedTop      []string
+}{
+	{
+		"Get the highlights of the last week",
+		"quibly",
+		"",
+		"",
+		http.StatusOK,
+		[]string{"Where should you ride a bike?"},
+		[]string{"What is the best bike?", "What is the worst bike?"},
+	},
+	{
+		"Get the highlights of a week with no top stories",
+		"quibly",
+		"",
+		"2021-01-08T00:00:00Z",
+		http.StatusOK,
+		[]string{"Where should you ride a bike?"},
+		[]string{},
+	},
+	{
+		"Get the highlights of a week, using when the stories were added",
+		"drawlosseum",
+		"2019-12-29T00:00:00Z",
+		"2020-01-05T00:00:00Z",
+		http.StatusOK,
+		[]string{},
+		[]string{"fish"},
+	},
+	{
+		"Get the highlights of the last week, using when the stories were added",
+		"drawlosseum",
+		"",
+		"",
+		http.StatusOK,
+		[]string{},
+		[]string{},
+	},
+	{
+		"Try to get the highlights with an invalid time",
+		"quibly",
+		"last week",
+		"",
+		http.StatusBadRequest,
+		[]string{},
+		[]string{},
+	},
+	{
+		"Try to get the highlights of a game that does not exist",
+		"quiblyv3",
+		"",
+		"",
+		http.StatusNotFound,
+		[]string{},
+		[]string{},
+	},
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/games"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/questions"
@@ -21,6 +22,7 @@ func (s *Tests) SubTestAddGame(t *testing.T) {
 			if ok && tc.ExpectedStatus == http.StatusCreated {
 				endpoint := fmt.Sprintf("/game/%s", gameData.Name)
 				s.httpExpect.DELETE(endpoint).
+					WithQuery("confirmation_token", s.previewRemoveGame(gameData.Name)).
 					Expect().
 					Status(http.StatusOK)
 			}
@@ -56,6 +58,23 @@ func (s *Tests) SubTestGetAllGames(t *testing.T) {
 	}
 }
 
+func (s *Tests) SubTestGetAvailableGames(t *testing.T) {
+	for _, tc := range data.GetAvailableGames {
+		testName := fmt.Sprintf("Get Available Games: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			response := s.httpExpect.GET("/game").
+				WithQuery("language", tc.Language).
+				WithQuery("region", tc.Region).
+				Expect().
+				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedStatus == http.StatusOK {
+				response.JSON().Array().Equal(tc.ExpectedNames)
+			}
+		})
+	}
+}
+
 func (s *Tests) SubTestGetGame(t *testing.T) {
 	for _, tc := range data.GetGame {
 		testName := fmt.Sprintf("Get Game: %s", tc.TestDescription)
@@ -70,17 +89,103 @@ func (s *Tests) SubTestRemoveGame(t *testing.T) {
 		testName := fmt.Sprintf("Remove Game: %s", tc.TestDescription)
 		t.Run(testName, func(t *testing.T) {
 			endpoint := fmt.Sprintf("/game/%s", tc.Name)
-			s.httpExpect.DELETE(endpoint).
+			if tc.SharedStoryID != "" {
+				s.httpExpect.POST(fmt.Sprintf("/story/%s/%s/share", tc.Name, tc.SharedStoryID)).
+					WithJSON(map[string]interface{}{}).
+					Expect().
+					Status(http.StatusCreated)
+			}
+
+			confirmationToken := tc.ConfirmationToken
+			if tc.Preview {
+				confirmationToken = s.previewRemoveGame(tc.Name)
+			} else if tc.StoredToken != nil {
+				_, err := tc.StoredToken.Add(s.DB)
+				if err != nil {
+					t.Fatalf("failed to add removal token %s", err)
+				}
+				confirmationToken = tc.StoredToken.Token
+			}
+
+			response := s.httpExpect.DELETE(endpoint).
+				WithQuery("dry_run", tc.DryRun).
+				WithQuery("confirmation_token", confirmationToken).
+				Expect().
+				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedStatus != http.StatusOK {
+				return
+			}
+
+			if tc.DryRun {
+				removal := response.JSON().Object().ContainsMap(tc.ExpectedResult)
+				removal.Value("confirmation_token").String().NotEmpty()
+				removal.Value("expires_at").String().NotEmpty()
+				s.httpExpect.GET(endpoint).
+					Expect().
+					Status(http.StatusOK)
+				return
+			}
+
+			response.JSON().Object().Equal(tc.ExpectedResult)
+			s.getGame(tc.Name, http.StatusNotFound, games.GameOut{})
+			response = s.getQuestionsByID(tc.Name, 5, "", http.StatusOK)
+			response.JSON().Object().Equal(questions.AllQuestionOut{
+				IDs:    []string{},
+				Cursor: "",
+			})
+
+			shares, err := s.DB.Count("share", map[string]interface{}{"game_name": tc.Name})
+			if err != nil {
+				t.Fatalf("failed to count share links %s", err)
+			} else if shares != 0 {
+				t.Errorf("expected the share links of the game to be removed, %d left", shares)
+			}
+		})
+	}
+}
+
+func (s *Tests) previewRemoveGame(name string) string {
+	return s.httpExpect.DELETE(fmt.Sprintf("/game/%s", name)).
+		WithQuery("dry_run", true).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("confirmation_token").String().Raw()
+}
+
+func (s *Tests) SubTestGetGameStats(t *testing.T) {
+	for _, tc := range data.GetGameStats {
+		testName := fmt.Sprintf("Get Game Stats: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			endpoint := fmt.Sprintf("/game/%s/stats", tc.Name)
+			response := s.httpExpect.GET(endpoint).
+				Expect().
+				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedStatus == http.StatusOK {
+				stats := response.JSON().Object()
+				stats.Value("questions").Equal(tc.ExpectedResult.Questions)
+				stats.Value("stories").Equal(tc.ExpectedResult.Stories)
+				stats.Value("translation_coverage").Equal(tc.ExpectedResult.TranslationCoverage)
+				stats.ContainsKey("latest_story")
+			}
+		})
+	}
+}
+
+func (s *Tests) SubTestUpdateGameMetadata(t *testing.T) {
+	for _, tc := range data.UpdateGameMetadata {
+		testName := fmt.Sprintf("Update Game Metadata: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			endpoint := fmt.Sprintf("/game/%s", tc.Name)
+			response := s.httpExpect.PATCH(endpoint).
+				WithJSON(tc.Payload).
 				Expect().
 				Status(tc.ExpectedStatus)
 
 			if tc.ExpectedStatus == http.StatusOK {
-				s.getGame(tc.Name, http.StatusNotFound, games.GameOut{})
-				response := s.getQuestionsByID(tc.Name, 5, "", http.StatusOK)
-				response.JSON().Object().Equal(questions.AllQuestionOut{
-					IDs:    []string{},
-					Cursor: "",
-				})
+				response.JSON().Object().Equal(tc.ExpectedGame)
+				s.getGame(tc.Name, http.StatusOK, tc.ExpectedGame)
 			}
 		})
 	}
@@ -118,6 +223,108 @@ func (s *Tests) SubTestDisableGame(t *testing.T) {
 	}
 }
 
+func (s *Tests) SubTestGetGameRules(t *testing.T) {
+	for _, tc := range data.GetGameRules {
+		testName := fmt.Sprintf("Get Game Rules: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			endpoint := fmt.Sprintf("/game/%s/rules", tc.Name)
+			response := s.httpExpect.GET(endpoint).
+				Expect().
+				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedStatus == http.StatusOK {
+				response.JSON().Object().Equal(tc.ExpectedRules)
+			}
+		})
+	}
+}
+
+func (s *Tests) SubTestGetRounds(t *testing.T) {
+	for _, tc := range data.GetRounds {
+		testName := fmt.Sprintf("Get Rounds: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			endpoint := fmt.Sprintf("/game/%s/round", tc.Name)
+			response := s.httpExpect.GET(endpoint).
+				Expect().
+				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedStatus == http.StatusOK {
+				response.JSON().Array().Equal(tc.ExpectedRounds)
+			}
+		})
+	}
+}
+
+func (s *Tests) SubTestUpdateGameRules(t *testing.T) {
+	for _, tc := range data.UpdateGameRules {
+		testName := fmt.Sprintf("Update Game Rules: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			endpoint := fmt.Sprintf("/game/%s/rules", tc.Name)
+			s.httpExpect.PUT(endpoint).
+				WithJSON(tc.Payload).
+				Expect().
+				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedStatus == http.StatusOK {
+				questionEndpoint := fmt.Sprintf("/game/%s/question", tc.Name)
+				s.httpExpect.POST(questionEndpoint).
+					WithJSON(tc.Question).
+					Expect().
+					Status(tc.QuestionStatus)
+			}
+		})
+	}
+}
+
+func (s *Tests) SubTestUpdateGameLanguage(t *testing.T) {
+	for _, tc := range data.UpdateGameLanguage {
+		testName := fmt.Sprintf("Update Game Language: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			action := "disable"
+			if tc.Enable {
+				action = "enable"
+			}
+
+			endpoint := fmt.Sprintf("/game/%s/%s", tc.Name, action)
+			s.httpExpect.PUT(endpoint).
+				WithQuery("language", tc.Language).
+				Expect().
+				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedStatus == http.StatusOK {
+				endpoint = fmt.Sprintf("/game/%s", tc.Name)
+				s.httpExpect.GET(endpoint).
+					Expect().
+					Status(http.StatusOK).
+					JSON().Object().Value("languages").Equal(tc.ExpectedLanguages)
+			}
+		})
+	}
+}
+
+func (s *Tests) SubTestPurgeExpiredStories(t *testing.T) {
+	for _, tc := range data.PurgeExpiredStories {
+		testName := fmt.Sprintf("Purge Expired Stories: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			for name, days := range tc.RetentionDays {
+				endpoint := fmt.Sprintf("/game/%s", name)
+				s.httpExpect.PATCH(endpoint).
+					WithJSON(games.GameMetadataIn{RetentionDays: &days}).
+					Expect().
+					Status(http.StatusOK)
+			}
+
+			gameService := games.GameService{DB: s.DB}
+			purged, err := gameService.PurgeExpiredStories(time.Now().AddDate(0, 0, tc.DaysFromNow))
+			if err != nil {
+				t.Fatalf("failed to purge expired stories %s", err)
+			}
+
+			s.httpExpect.Value(purged).Equal(tc.ExpectedPurged)
+		})
+	}
+}
+
 func (s *Tests) getGame(game string, expectedStatus int, expectedResult games.GameOut) {
 	endpoint := fmt.Sprintf("/game/%s", game)
 	response := s.httpExpect.GET(endpoint).
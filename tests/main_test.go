@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/api"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/apikey"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/core"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/core/database"
 	"gitlab.com/banter-bus/banter-bus-management-api/internal/games"
@@ -19,8 +20,12 @@ import (
 	"github.com/houqp/gtest"
 )
 
+// pseudonymKey is only used by the tests, the key must be set in the environment everywhere else to redact players.
+const pseudonymKey = "test-pseudonym-key"
+
 type Tests struct {
 	httpExpect *httpexpect.Expect
+	anonymous  *httpexpect.Expect
 	DB         database.Database
 }
 
@@ -45,10 +50,12 @@ type TestData interface {
 
 func (s *Tests) Setup(t *testing.T) {
 	os.Setenv("BANTER_BUS_CONFIG_PATH", "config.test.yml")
+	os.Setenv("BANTER_BUS_PRIVACY_PSEUDONYM_KEY", pseudonymKey)
 	conf, err := core.NewConfig()
 	if err != nil {
 		fmt.Printf("Failed to load config %s", err)
 	}
+	api.RegisterGames(conf)
 	logger := core.SetupLogger(ioutil.Discard)
 	core.UpdateLogLevel(logger, "DEBUG")
 	db, err := database.NewMongoDB(logger,
@@ -71,7 +78,7 @@ func (s *Tests) Setup(t *testing.T) {
 	}
 
 	s.DB = db
-	s.httpExpect = httpexpect.WithConfig(httpexpect.Config{
+	s.anonymous = httpexpect.WithConfig(httpexpect.Config{
 		Client: &http.Client{
 			Transport: httpexpect.NewBinder(router.Engine()),
 			Jar:       httpexpect.NewJar(),
@@ -81,9 +88,29 @@ func (s *Tests) Setup(t *testing.T) {
 			httpexpect.NewDebugPrinter(t, true),
 		},
 	})
+
+	adminKey := s.newAPIKey(apikey.Admin)
+	s.httpExpect = s.anonymous.Builder(func(req *httpexpect.Request) {
+		req.WithHeader(apikey.Header, adminKey)
+	})
 }
 
-func (s *Tests) Teardown(t *testing.T) {}
+func (s *Tests) Teardown(t *testing.T) {
+	err := s.DB.RemoveCollection("api_key")
+	if err != nil {
+		fmt.Printf("Failed to remove collection api_key %s", err)
+	}
+}
+
+func (s *Tests) newAPIKey(role apikey.Role) string {
+	keyService := apikey.APIKeyService{DB: s.DB}
+	_, key, err := keyService.Add(fmt.Sprintf("%s-test", role), role)
+	if err != nil {
+		fmt.Printf("Failed to add API key %s", err)
+	}
+
+	return key
+}
 
 func (s *Tests) BeforeEach(t *testing.T) {
 	gameData := GameTestData{
@@ -117,6 +144,16 @@ func (s *Tests) AfterEach(t *testing.T) {
 	if err != nil {
 		fmt.Printf("Failed to remove collection story %s", err)
 	}
+
+	err = s.DB.RemoveCollection("share")
+	if err != nil {
+		fmt.Printf("Failed to remove collection share %s", err)
+	}
+
+	err = s.DB.RemoveCollection("removal")
+	if err != nil {
+		fmt.Printf("Failed to remove collection removal %s", err)
+	}
 }
 
 func TestSampleTests(t *testing.T) {
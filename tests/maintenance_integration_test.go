@@ -0,0 +1,55 @@
+package controllers_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/core/database"
+	"gitlab.com/banter-bus/banter-bus-management-api/tests/data"
+)
+
+func (s *Tests) SubTestHealthcheck(t *testing.T) {
+	s.httpExpect.GET("/healthcheck").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().ValueEqual("message", "The API is healthy.")
+}
+
+func (s *Tests) SubTestLivez(t *testing.T) {
+	s.httpExpect.GET("/livez").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().ValueEqual("status", "ok")
+}
+
+func (s *Tests) SubTestReadyz(t *testing.T) {
+	for _, tc := range data.GetReadiness {
+		testName := fmt.Sprintf("Readyz: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			// Collections, and so their indexes, are removed after each test.
+			err := s.DB.(*database.MongoDB).EnsureIndexes()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.RemoveCollection != "" {
+				err = s.DB.RemoveCollection(tc.RemoveCollection)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			readiness := s.httpExpect.GET("/readyz").
+				Expect().
+				Status(tc.ExpectedStatus).
+				JSON().Object()
+
+			readiness.ValueEqual("ready", tc.ExpectedStatus == http.StatusOK)
+			checks := readiness.Value("checks").Object()
+			for name, ready := range tc.ExpectedChecks {
+				checks.Value(name).Object().ValueEqual("ready", ready)
+			}
+		})
+	}
+}
@@ -117,6 +117,60 @@ func (s *Tests) SubTestGetQuestionByID(t *testing.T) {
 	}
 }
 
+func (s *Tests) SubTestGetFullQuestion(t *testing.T) {
+	for _, tc := range data.GetFullQuestion {
+		testName := fmt.Sprintf("Get Full Question: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			endpoint := fmt.Sprintf("/game/%s/question/%s", tc.Game, tc.ID)
+			response := s.httpExpect.GET(endpoint).
+				Expect().
+				Status(tc.Expected)
+
+			if tc.Expected == http.StatusOK {
+				response.JSON().Equal(tc.ExpectedPayload)
+			}
+		})
+	}
+}
+
+func (s *Tests) SubTestRenderQuestion(t *testing.T) {
+	for _, tc := range data.RenderQuestion {
+		testName := fmt.Sprintf("Render Question: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			endpoint := fmt.Sprintf("/game/%s/question/%s/%s/render", tc.Game, tc.ID, tc.LanguageCode)
+			response := s.httpExpect.POST(endpoint).
+				WithJSON(tc.Payload).
+				Expect().
+				Status(tc.Expected)
+
+			if tc.Expected == http.StatusOK {
+				response.JSON().Equal(tc.ExpectedPayload)
+			}
+		})
+	}
+}
+
+func (s *Tests) SubTestRenderAddedQuestion(t *testing.T) {
+	for _, tc := range data.RenderAddedQuestion {
+		testName := fmt.Sprintf("Render Added Question: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			endpoint := fmt.Sprintf("/game/%s/question", tc.Game)
+			id := s.httpExpect.POST(endpoint).
+				WithJSON(tc.Question).
+				Expect().
+				Status(http.StatusCreated).
+				JSON().String().Raw()
+
+			endpoint = fmt.Sprintf("/game/%s/question/%s/en/render", tc.Game, id)
+			s.httpExpect.POST(endpoint).
+				WithJSON(tc.Payload).
+				Expect().
+				Status(http.StatusOK).
+				JSON().Equal(tc.ExpectedPayload)
+		})
+	}
+}
+
 func (s *Tests) SubTestEnableQuestion(t *testing.T) {
 	for _, tc := range data.EnableQuestion {
 		testName := fmt.Sprintf("Enable Question: %s", tc.TestDescription)
@@ -0,0 +1,70 @@
+package controllers_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/core"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/games"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/questions"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/seed"
+	"gitlab.com/banter-bus/banter-bus-management-api/tests/data"
+)
+
+func (s *Tests) SubTestApplySeed(t *testing.T) {
+	for _, tc := range data.ApplySeed {
+		testName := fmt.Sprintf("Apply Seed: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			service := seed.SeedService{DB: s.DB, Logger: core.SetupLogger(ioutil.Discard), Update: tc.Update}
+			report, err := service.Apply(tc.Seed)
+			if tc.ExpectError {
+				if err == nil {
+					t.Errorf("expected seed to fail")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("failed to apply seed %s", err)
+			} else if report != tc.ExpectedReport {
+				t.Errorf("expected report %+v, got %+v", tc.ExpectedReport, report)
+			}
+
+			report, err = service.Apply(tc.Seed)
+			if err != nil || report.Games.Created+report.Questions.Created > 0 {
+				t.Errorf("expected seed to be idempotent, got report %+v %v", report, err)
+			}
+		})
+	}
+}
+
+func (s *Tests) SubTestApplySeedUpdate(t *testing.T) {
+	for _, tc := range data.ApplySeedUpdate {
+		testName := fmt.Sprintf("Apply Seed Update: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			service := seed.SeedService{DB: s.DB, Logger: core.SetupLogger(ioutil.Discard), Update: true}
+			_, err := service.Apply(tc.Seed)
+			if err != nil {
+				t.Fatalf("failed to apply seed %s", err)
+			}
+
+			game := &games.Game{}
+			err = game.Get(s.DB, map[string]interface{}{"name": tc.ExpectedGame.Name})
+			if err != nil {
+				t.Fatalf("failed to get game %s", err)
+			} else if !reflect.DeepEqual(*game, tc.ExpectedGame) {
+				t.Errorf("expected game %+v, got %+v", tc.ExpectedGame, *game)
+			}
+
+			question := &questions.Question{}
+			err = question.Get(s.DB, map[string]interface{}{"id": tc.QuestionID})
+			if err != nil {
+				t.Fatalf("failed to get question %s", err)
+			} else if !reflect.DeepEqual(question.Content, tc.ExpectedContent) {
+				t.Errorf("expected question content %v, got %v", tc.ExpectedContent, question.Content)
+			}
+		})
+	}
+}
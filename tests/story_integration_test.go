@@ -3,8 +3,12 @@ package controllers_test
 import (
 	"fmt"
 	"net/http"
+	"sync"
 	"testing"
+	"time"
 
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/core/database"
+	"gitlab.com/banter-bus/banter-bus-management-api/internal/story"
 	"gitlab.com/banter-bus/banter-bus-management-api/tests/data"
 )
 
@@ -13,10 +17,92 @@ func (s *Tests) SubTestAddStories(t *testing.T) {
 		testName := fmt.Sprintf("Add Story: %s", tc.TestDescription)
 		t.Run(testName, func(t *testing.T) {
 			endpoint := fmt.Sprintf("/story/%s", tc.GameName)
-			s.httpExpect.POST(endpoint).
+			response := s.httpExpect.POST(endpoint).
 				WithJSON(tc.Payload).
 				Expect().
 				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedMessage != "" {
+				response.JSON().Object().ValueEqual("message", tc.ExpectedMessage)
+			}
+		})
+	}
+}
+
+func (s *Tests) SubTestAddStoryWithMetadata(t *testing.T) {
+	for _, tc := range data.AddStoryWithMetadata {
+		testName := fmt.Sprintf("Add Story With Metadata: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			endpoint := fmt.Sprintf("/story/%s", tc.GameName)
+			response := s.httpExpect.POST(endpoint).
+				WithJSON(tc.Payload).
+				Expect().
+				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedStatus != http.StatusCreated {
+				return
+			}
+
+			storyID := response.JSON().String().Raw()
+			endpoint = fmt.Sprintf("/story/%s/%s", tc.GameName, storyID)
+			story := s.httpExpect.GET(endpoint).
+				Expect().
+				Status(http.StatusOK).
+				JSON().Object()
+
+			story.Value("created_at").String().NotEmpty()
+			if tc.Payload.Metadata != nil {
+				story.Value("metadata").Equal(tc.Payload.Metadata)
+			} else {
+				story.NotContainsKey("metadata")
+			}
+
+			if tc.Payload.Metadata != nil && tc.Payload.Metadata.QuestionID != "" {
+				endpoint = fmt.Sprintf("/game/%s/stats", tc.GameName)
+				s.httpExpect.GET(endpoint).
+					Expect().
+					Status(http.StatusOK).
+					JSON().Object().Path("$.questions.stories").Object().
+					ValueEqual(tc.Payload.Metadata.QuestionID, 1)
+			}
+		})
+	}
+}
+
+func (s *Tests) SubTestListStories(t *testing.T) {
+	for _, tc := range data.ListStories {
+		testName := fmt.Sprintf("List Stories: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			endpoint := fmt.Sprintf("/story/%s", tc.GameName)
+			response := s.httpExpect.GET(endpoint).
+				WithQueryObject(tc.Query).
+				Expect().
+				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedStatus != http.StatusOK {
+				return
+			}
+
+			ids := []string{}
+			for {
+				page := response.JSON().Object()
+				for _, story := range page.Value("stories").Array().Iter() {
+					ids = append(ids, story.Object().Value("id").String().Raw())
+				}
+
+				cursor := page.Value("cursor").String().Raw()
+				if cursor == "" {
+					break
+				}
+
+				response = s.httpExpect.GET(endpoint).
+					WithQueryObject(tc.Query).
+					WithQuery("cursor", cursor).
+					Expect().
+					Status(http.StatusOK)
+			}
+
+			s.httpExpect.Value(ids).Equal(tc.ExpectedIDs)
 		})
 	}
 }
@@ -37,6 +123,47 @@ func (s *Tests) SubTestGetStories(t *testing.T) {
 	}
 }
 
+func (s *Tests) SubTestGetStoryImage(t *testing.T) {
+	for _, tc := range data.GetStoryImage {
+		testName := fmt.Sprintf("Get Story Image: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			endpoint := fmt.Sprintf("/story/%s/%s/image", tc.GameName, tc.StoryID)
+			response := s.httpExpect.GET(endpoint).
+				WithQuery("format", tc.Format).
+				Expect().
+				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedStatus == http.StatusOK {
+				response.ContentType(tc.ExpectedContentType)
+				response.Body().NotEmpty()
+			}
+		})
+	}
+}
+
+func (s *Tests) SubTestGetStoryTranscript(t *testing.T) {
+	for _, tc := range data.GetStoryTranscript {
+		testName := fmt.Sprintf("Get Story Transcript: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			endpoint := fmt.Sprintf("/story/%s/%s/transcript", tc.GameName, tc.StoryID)
+			response := s.httpExpect.GET(endpoint).
+				WithQuery("format", tc.Format).
+				Expect().
+				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedStatus != http.StatusOK {
+				return
+			}
+
+			response.ContentType(tc.ExpectedContentType, "utf-8")
+			body := response.Body()
+			for _, content := range tc.ExpectedContent {
+				body.Contains(content)
+			}
+		})
+	}
+}
+
 func (s *Tests) SubTestDeleteStories(t *testing.T) {
 	for _, tc := range data.GetStories {
 		testName := fmt.Sprintf("Delete Story: %s", tc.TestDescription)
@@ -48,3 +175,418 @@ func (s *Tests) SubTestDeleteStories(t *testing.T) {
 		})
 	}
 }
+
+func (s *Tests) SubTestPurgeStories(t *testing.T) {
+	for _, tc := range data.PurgeStories {
+		testName := fmt.Sprintf("Purge Stories: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			endpoint := fmt.Sprintf("/story/%s/purge", tc.GameName)
+			response := s.httpExpect.POST(endpoint).
+				WithJSON(tc.Payload).
+				Expect().
+				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedStatus == http.StatusOK {
+				response.JSON().Object().Value("deleted").Equal(tc.ExpectedDeleted)
+			}
+		})
+	}
+}
+
+func (s *Tests) SubTestCompactDrawings(t *testing.T) {
+	for _, tc := range data.CompactDrawings {
+		testName := fmt.Sprintf("Compact Drawings: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			for _, legacy := range tc.LegacyStories {
+				inserted, err := s.DB.Insert("story", legacyStory(legacy))
+				if !inserted || err != nil {
+					t.Fatalf("failed to insert legacy story %s", err)
+				}
+			}
+
+			storyService := story.StoryService{DB: s.DB}
+			migrated, err := storyService.CompactDrawings("drawlosseum", tc.BatchSize)
+			if err != nil {
+				t.Fatalf("failed to compact drawings %s", err)
+			}
+			s.httpExpect.Value(migrated).Equal(tc.ExpectedMigrated)
+
+			migrated, err = storyService.CompactDrawings("drawlosseum", tc.BatchSize)
+			if err != nil {
+				t.Fatalf("failed to compact drawings again %s", err)
+			}
+			s.httpExpect.Value(migrated).Equal(0)
+
+			endpoint := fmt.Sprintf("/story/%s/%s", tc.LegacyStories[0]["game_name"], tc.LegacyStories[0]["id"])
+			s.httpExpect.GET(endpoint).
+				Expect().
+				Status(http.StatusOK).
+				JSON().Object().Equal(tc.ExpectedResult)
+		})
+	}
+}
+
+func (s *Tests) SubTestGetQuiblyResults(t *testing.T) {
+	for _, tc := range data.GetQuiblyResults {
+		testName := fmt.Sprintf("Get Quibly Results: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			storyID := s.httpExpect.POST("/story/quibly").
+				WithJSON(tc.Payload).
+				Expect().
+				Status(http.StatusCreated).
+				JSON().String().Raw()
+
+			endpoint := fmt.Sprintf("/story/quibly/%s", storyID)
+			s.httpExpect.GET(endpoint).
+				Expect().
+				Status(http.StatusOK).
+				JSON().Object().Value("results").Equal(tc.ExpectedResults)
+		})
+	}
+}
+
+func (s *Tests) SubTestGetBestAnswers(t *testing.T) {
+	for _, payload := range data.QuiblyStories {
+		s.httpExpect.POST("/story/quibly").
+			WithJSON(payload).
+			Expect().
+			Status(http.StatusCreated)
+	}
+
+	for _, tc := range data.GetBestAnswers {
+		testName := fmt.Sprintf("Get Best Answers: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			endpoint := fmt.Sprintf("/story/%s/player/%s/best", tc.GameName, tc.Nickname)
+			response := s.httpExpect.GET(endpoint).
+				WithQueryObject(tc.Query).
+				Expect().
+				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedStatus != http.StatusOK {
+				return
+			}
+
+			answers := response.JSON().Object().Value("answers").Array()
+			answers.Length().Equal(len(tc.ExpectedAnswers))
+			for i, expected := range tc.ExpectedAnswers {
+				answer := answers.Element(i).Object()
+				answer.Value("story_id").String().NotEmpty()
+				answer.ValueEqual("nickname", expected.Nickname)
+				answer.ValueEqual("answer", expected.Answer)
+				answer.ValueEqual("votes", expected.Votes)
+				answer.ValueEqual("rank", expected.Rank)
+				answer.ValueEqual("share", expected.Share)
+			}
+		})
+	}
+}
+
+func (s *Tests) SubTestAppendAnswers(t *testing.T) {
+	for _, tc := range data.AppendAnswers {
+		testName := fmt.Sprintf("Append Answers: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			storyID := s.addStory(tc.GameName, tc.Payload)
+			endpoint := fmt.Sprintf("/story/%s/%s/answers", tc.GameName, storyID)
+			response := s.httpExpect.POST(endpoint).
+				WithJSON(tc.Answers).
+				Expect().
+				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedStatus != http.StatusOK {
+				return
+			}
+
+			story := response.JSON().Object()
+			story.ValueEqual("in_progress", true)
+			story.ContainsMap(tc.ExpectedAnswers)
+		})
+	}
+}
+
+func (s *Tests) SubTestAppendAnswersConcurrently(t *testing.T) {
+	for _, tc := range data.AppendAnswersConcurrently {
+		testName := fmt.Sprintf("Append Answers Concurrently: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			storyID := s.addStory(tc.GameName, tc.Payload)
+			endpoint := fmt.Sprintf("/story/%s/%s/answers", tc.GameName, storyID)
+
+			var wg sync.WaitGroup
+			for _, answers := range tc.Answers {
+				wg.Add(1)
+				go func(answers story.StoryAnswersInOut) {
+					defer wg.Done()
+					s.httpExpect.POST(endpoint).
+						WithJSON(answers).
+						Expect().
+						Status(http.StatusOK)
+				}(answers)
+			}
+			wg.Wait()
+
+			answers := s.httpExpect.GET(fmt.Sprintf("/story/%s/%s", tc.GameName, storyID)).
+				Expect().
+				Status(http.StatusOK).
+				JSON().Object().
+				Value(tc.GameName).Array()
+			answers.Length().Equal(len(tc.ExpectedAnswers))
+			answers.Contains(tc.ExpectedAnswers...)
+		})
+	}
+}
+
+func (s *Tests) SubTestFinaliseStory(t *testing.T) {
+	for _, tc := range data.FinaliseStory {
+		testName := fmt.Sprintf("Finalise Story: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			storyID := s.addStory("fibbing_it", tc.Payload)
+			endpoint := fmt.Sprintf("/story/fibbing_it/%s/finalise", storyID)
+			for i := 1; i < tc.Finalise; i++ {
+				s.httpExpect.PUT(endpoint).
+					Expect().
+					Status(http.StatusOK)
+			}
+
+			response := s.httpExpect.PUT(endpoint).
+				Expect().
+				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedStatus == http.StatusOK {
+				response.JSON().Object().NotContainsKey("in_progress")
+			}
+		})
+	}
+}
+
+func (s *Tests) SubTestShareStory(t *testing.T) {
+	for _, tc := range data.ShareStory {
+		testName := fmt.Sprintf("Share Story: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			endpoint := fmt.Sprintf("/story/%s/%s/share", tc.GameName, tc.StoryID)
+			response := s.httpExpect.POST(endpoint).
+				WithJSON(tc.Payload).
+				Expect().
+				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedStatus != http.StatusCreated {
+				return
+			}
+
+			token := response.JSON().Object().Value("token").String().NotEmpty().Raw()
+			s.httpExpect.GET(fmt.Sprintf("/share/%s", token)).
+				Expect().
+				Status(http.StatusOK).
+				JSON().Equal(tc.ExpectedResult)
+
+			s.httpExpect.DELETE(fmt.Sprintf("%s/%s", endpoint, token)).
+				Expect().
+				Status(http.StatusOK)
+
+			s.httpExpect.GET(fmt.Sprintf("/share/%s", token)).
+				Expect().
+				Status(http.StatusNotFound)
+
+			s.httpExpect.DELETE(fmt.Sprintf("%s/%s", endpoint, token)).
+				Expect().
+				Status(http.StatusNotFound)
+		})
+	}
+}
+
+func (s *Tests) SubTestGetExpiredShare(t *testing.T) {
+	expiresAt := time.Now().Add(-time.Hour)
+	share := story.Share{
+		Token:     "expired-token",
+		GameName:  "quibly",
+		StoryID:   "1def4233-f674-4a3f-863d-6e850bfbfdb4",
+		CreatedAt: time.Now().Add(-2 * time.Hour),
+		ExpiresAt: &expiresAt,
+	}
+
+	inserted, err := share.Add(s.DB)
+	if !inserted || err != nil {
+		t.Fatalf("failed to insert share link %s", err)
+	}
+
+	s.httpExpect.GET("/share/expired-token").
+		Expect().
+		Status(http.StatusNotFound)
+}
+
+func (s *Tests) SubTestErasePlayer(t *testing.T) {
+	for _, tc := range data.ErasePlayer {
+		testName := fmt.Sprintf("Erase Player: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			response := s.httpExpect.POST("/player/erase").
+				WithJSON(tc.Payload).
+				Expect().
+				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedStatus != http.StatusOK {
+				return
+			}
+
+			report := response.JSON().Object()
+			stories := report.Value("stories").Array()
+			stories.Length().Equal(len(tc.ExpectedStories))
+			for _, expected := range tc.ExpectedStories {
+				stories.Contains(expected)
+			}
+
+			nickname := tc.Payload["nickname"].(string)
+			for _, expected := range tc.ExpectedStories {
+				endpoint := fmt.Sprintf("/story/%s/%s", expected.GameName, expected.StoryID)
+				if tc.Payload["action"] == "delete" {
+					s.httpExpect.GET(endpoint).Expect().Status(http.StatusNotFound)
+					continue
+				}
+
+				pseudonym := story.NewPseudonym(pseudonymKey, nickname)
+				report.ValueEqual("pseudonym", pseudonym)
+				storyOut := s.httpExpect.GET(endpoint).Expect().Status(http.StatusOK).Body()
+				storyOut.NotContains(fmt.Sprintf("%q", nickname))
+				storyOut.Contains(pseudonym)
+			}
+		})
+	}
+}
+
+func (s *Tests) SubTestLikeStory(t *testing.T) {
+	for _, tc := range data.LikeStory {
+		testName := fmt.Sprintf("Like Story: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			endpoint := fmt.Sprintf("/story/%s/%s/like", tc.GameName, tc.StoryID)
+			for i := 0; i < tc.Likes; i++ {
+				response := s.httpExpect.POST(endpoint).
+					Expect().
+					Status(tc.ExpectedStatus)
+
+				if tc.ExpectedStatus == http.StatusOK {
+					response.JSON().Object().ValueEqual("likes", i+1)
+				}
+			}
+
+			for i := 0; i < tc.Unlikes; i++ {
+				s.httpExpect.DELETE(endpoint).
+					Expect().
+					Status(tc.ExpectedStatus)
+			}
+
+			if tc.ExpectedStatus != http.StatusOK {
+				return
+			}
+
+			storyOut := s.httpExpect.GET(fmt.Sprintf("/story/%s/%s", tc.GameName, tc.StoryID)).
+				Expect().
+				Status(http.StatusOK).
+				JSON().Object()
+
+			if tc.ExpectedLikes == 0 {
+				storyOut.NotContainsKey("likes")
+			} else {
+				storyOut.ValueEqual("likes", tc.ExpectedLikes)
+			}
+		})
+	}
+}
+
+func (s *Tests) SubTestFeatureStory(t *testing.T) {
+	for _, tc := range data.FeatureStory {
+		testName := fmt.Sprintf("Feature Story: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			endpoint := fmt.Sprintf("/story/%s/%s/featured", tc.GameName, tc.StoryID)
+			response := s.httpExpect.PUT(endpoint).
+				WithJSON(tc.Payload).
+				Expect().
+				Status(tc.ExpectedStatus)
+
+			if tc.ExpectedStatus != http.StatusOK {
+				return
+			}
+
+			if tc.ExpectedResult {
+				response.JSON().Object().ValueEqual("featured", true)
+			} else {
+				response.JSON().Object().NotContainsKey("featured")
+			}
+		})
+	}
+}
+
+func (s *Tests) SubTestGetHighlights(t *testing.T) {
+	storyIDs := []string{}
+	for _, payload := range data.HighlightStories {
+		storyIDs = append(storyIDs, s.addStory("quibly", payload))
+	}
+
+	for i, likes := range []int{2, 1, 0} {
+		for j := 0; j < likes; j++ {
+			s.httpExpect.POST(fmt.Sprintf("/story/quibly/%s/like", storyIDs[i])).
+				Expect().
+				Status(http.StatusOK)
+		}
+	}
+
+	s.httpExpect.PUT(fmt.Sprintf("/story/quibly/%s/featured", storyIDs[2])).
+		WithJSON(map[string]interface{}{"featured": true}).
+		Expect().
+		Status(http.StatusOK)
+
+	s.httpExpect.POST("/story/drawlosseum/a4ffd1c8-93c5-4f4c-8ace-71996edcbcb7/like").
+		Expect().
+		Status(http.StatusOK)
+
+	for _, tc := range data.GetHighlights {
+		testName := fmt.Sprintf("Get Highlights: %s", tc.TestDescription)
+		t.Run(testName, func(t *testing.T) {
+			request := s.httpExpect.GET(fmt.Sprintf("/story/%s/highlights", tc.GameName))
+			if tc.From != "" {
+				request = request.WithQuery("from", tc.From)
+			}
+			if tc.To != "" {
+				request = request.WithQuery("to", tc.To)
+			}
+
+			response := request.Expect().Status(tc.ExpectedStatus)
+			if tc.ExpectedStatus != http.StatusOK {
+				return
+			}
+
+			highlights := response.JSON().Object()
+			featured := highlights.Value("featured").Array()
+			featured.Length().Equal(len(tc.ExpectedFeatured))
+			for i, question := range tc.ExpectedFeatured {
+				featured.Element(i).Object().ValueEqual("question", question)
+			}
+
+			top := highlights.Value("top").Array()
+			top.Length().Equal(len(tc.ExpectedTop))
+			for i, question := range tc.ExpectedTop {
+				top.Element(i).Object().ValueEqual("question", question)
+			}
+		})
+	}
+}
+
+func (s *Tests) addStory(gameName string, payload story.StoryInOut) string {
+	endpoint := fmt.Sprintf("/story/%s", gameName)
+	return s.httpExpect.POST(endpoint).
+		WithJSON(payload).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().String().Raw()
+}
+
+// legacyStory is a story document as it was stored before drawings were compacted.
+type legacyStory map[string]interface{}
+
+func (l legacyStory) Add(db database.Database) (bool, error) {
+	return db.Insert("story", l)
+}
+
+func (l legacyStory) Get(db database.Database, filter map[string]interface{}) error {
+	return db.Get("story", filter, &l)
+}
+
+func (l legacyStory) Update(db database.Database, filter map[string]interface{}) (bool, error) {
+	return db.Update("story", filter, l)
+}
@@ -19,6 +19,7 @@ func TestGetOpenAPI(_ *testing.T) {
 		fmt.Printf("unable to load config %v", err)
 	}
 
+	api.RegisterGames(config)
 	env := &api.Env{Logger: nil, Conf: config, DB: nil}
 	router, err := api.Setup(env)
 	if err != nil {